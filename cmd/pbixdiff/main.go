@@ -0,0 +1,51 @@
+// Command pbixdiff compares the contents of two PBIX files, printing changes to pages, visuals,
+// measures, Power Query queries and connections. Like diff, it exits with 0 when there are no
+// changes, 1 when there are changes and 2 when the files cannot be compared.
+//
+//	pbixdiff [-format text|json] before.pbix after.pbix
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/codecutout/terraform-provider-powerbi/internal/pbixrewriter"
+)
+
+func main() {
+	format := flag.String("format", "text", "output format, either text or json")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: pbixdiff [-format text|json] before.pbix after.pbix\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 2 || (*format != "text" && *format != "json") {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	changes, err := pbixrewriter.DiffPbixFiles(flag.Arg(0), flag.Arg(1))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	if *format == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(changes); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+	} else {
+		fmt.Print(pbixrewriter.FormatPbixChanges(changes))
+	}
+
+	if len(changes) > 0 {
+		os.Exit(1)
+	}
+}
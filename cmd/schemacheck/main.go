@@ -0,0 +1,85 @@
+// Command schemacheck reports changes to the provider schema that can break existing configurations.
+// It compares the schemas of the resources and data sources against a snapshot saved from a previous
+// version, and exits with 0 when there are no breaking changes, 1 when there are breaking changes and
+// 2 when the schemas cannot be compared.
+//
+//	schemacheck -output schema.json
+//	schemacheck [-format text|json] [-output schema.json] previous.json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/codecutout/terraform-provider-powerbi/internal/docgen"
+	"github.com/codecutout/terraform-provider-powerbi/internal/powerbi"
+)
+
+func main() {
+	output := flag.String("output", "", "file to save a snapshot of the current schema to")
+	format := flag.String("format", "text", "output format, either text or json")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: schemacheck -output schema.json\n       schemacheck [-format text|json] [-output schema.json] previous.json\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if (*output == "" && flag.NArg() != 1) || flag.NArg() > 1 || (*format != "text" && *format != "json") {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// the previous snapshot is read before saving so the same file can be compared and updated
+	var previous *docgen.SchemaSnapshot
+	if flag.NArg() == 1 {
+		previousBytes, err := ioutil.ReadFile(flag.Arg(0))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		previous = &docgen.SchemaSnapshot{}
+		if err := json.Unmarshal(previousBytes, previous); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to read schema snapshot %s: %s\n", flag.Arg(0), err)
+			os.Exit(2)
+		}
+	}
+
+	current := docgen.NewSchemaSnapshot(powerbi.Provider())
+
+	if *output != "" {
+		snapshotBytes, err := json.MarshalIndent(current, "", "  ")
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		if err := ioutil.WriteFile(*output, append(snapshotBytes, '\n'), 0644); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+	}
+
+	if previous == nil {
+		return
+	}
+
+	changes := docgen.CompareSchemaSnapshots(previous, current)
+	if *format == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(changes); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+	} else {
+		for _, change := range changes {
+			fmt.Println(change)
+		}
+	}
+
+	if len(changes) > 0 {
+		os.Exit(1)
+	}
+}
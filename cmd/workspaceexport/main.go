@@ -0,0 +1,65 @@
+// Command workspaceexport writes Terraform configuration for existing workspaces, including
+// import blocks so the existing objects are brought under management by terraform plan.
+// Credentials are read from the same POWERBI_* environment variables as the provider.
+//
+//	workspaceexport [-output file.tf] workspace_id...
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/codecutout/terraform-provider-powerbi/internal/powerbi"
+	"github.com/codecutout/terraform-provider-powerbi/internal/powerbiapi"
+	"github.com/codecutout/terraform-provider-powerbi/internal/workspaceexport"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func main() {
+	output := flag.String("output", "", "file to write the configuration to, defaults to standard output")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: workspaceexport [-output file.tf] workspace_id...\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// configuring the provider without any values uses the environment variables for authentication
+	provider := powerbi.Provider()
+	if err := provider.Configure(terraform.NewResourceConfigRaw(map[string]interface{}{})); err != nil {
+		exit(err)
+	}
+	client := provider.Meta().(*powerbiapi.Client)
+
+	workspaces := []*workspaceexport.Workspace{}
+	for _, groupID := range flag.Args() {
+		workspace, err := workspaceexport.ReadWorkspace(client, groupID)
+		if err != nil {
+			exit(err)
+		}
+		workspaces = append(workspaces, workspace)
+	}
+
+	out := os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			exit(err)
+		}
+		defer file.Close()
+		out = file
+	}
+	if err := workspaceexport.WriteHCL(out, workspaces); err != nil {
+		exit(err)
+	}
+}
+
+func exit(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
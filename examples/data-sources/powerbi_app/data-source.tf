@@ -0,0 +1,3 @@
+data "powerbi_app" "example" {
+  name = "Sales"
+}
@@ -0,0 +1,4 @@
+data "powerbi_app_dashboard" "example" {
+  app_id       = data.powerbi_app.example.id
+  display_name = "Sales Overview"
+}
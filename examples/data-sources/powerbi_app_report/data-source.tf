@@ -0,0 +1,4 @@
+data "powerbi_app_report" "example" {
+  app_id = data.powerbi_app.example.id
+  name   = "Sales Overview"
+}
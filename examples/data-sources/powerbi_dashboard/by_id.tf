@@ -0,0 +1,4 @@
+data "powerbi_dashboard" "existing" {
+  workspace_id = "workspace-12345"
+  id           = "dashboard-67890"
+}
@@ -0,0 +1,12 @@
+data "powerbi_workspace" "example" {
+  name = "Example Workspace"
+}
+
+data "powerbi_dashboard" "sales" {
+  workspace_id = data.powerbi_workspace.example.id
+  name         = "Sales Dashboard"
+}
+
+output "dashboard_url" {
+  value = data.powerbi_dashboard.sales.web_url
+}
@@ -0,0 +1,10 @@
+locals {
+  revenue_tiles = [
+    for tile in data.powerbi_dashboard_tiles.sales_tiles.tiles : tile
+    if can(regex("(?i)revenue", tile.title))
+  ]
+}
+
+output "revenue_tile_ids" {
+  value = [for tile in local.revenue_tiles : tile.id]
+}
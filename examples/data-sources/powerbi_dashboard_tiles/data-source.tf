@@ -0,0 +1,21 @@
+data "powerbi_workspace" "example" {
+  name = "Example Workspace"
+}
+
+data "powerbi_dashboard" "sales" {
+  workspace_id = data.powerbi_workspace.example.id
+  name         = "Sales Dashboard"
+}
+
+data "powerbi_dashboard_tiles" "sales_tiles" {
+  workspace_id = data.powerbi_workspace.example.id
+  dashboard_id = data.powerbi_dashboard.sales.id
+}
+
+output "tile_count" {
+  value = length(data.powerbi_dashboard_tiles.sales_tiles.tiles)
+}
+
+output "tile_titles" {
+  value = [for tile in data.powerbi_dashboard_tiles.sales_tiles.tiles : tile.title]
+}
@@ -0,0 +1,4 @@
+data "powerbi_dataflow" "example" {
+  workspace_id = powerbi_workspace.example.id
+  name         = "Staging"
+}
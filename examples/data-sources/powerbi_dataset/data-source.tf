@@ -0,0 +1,12 @@
+data "powerbi_dataset" "shared_model" {
+  workspace_id = data.powerbi_workspace.shared.id
+  name         = "Sales Model"
+}
+
+resource "powerbi_report" "sales" {
+  workspace_id        = powerbi_workspace.example.id
+  name                = "Sales"
+  source_workspace_id = powerbi_workspace.templates.id
+  source_report_id    = powerbi_pbix.template.report_id
+  dataset_id          = data.powerbi_dataset.shared_model.dataset_id
+}
@@ -0,0 +1,10 @@
+data "powerbi_dataset_discover_gateways" "example" {
+  workspace_id = powerbi_workspace.example.id
+  dataset_id   = powerbi_pbix.example.dataset_id
+}
+
+resource "powerbi_dataset_gateway_binding" "example" {
+  workspace_id = powerbi_workspace.example.id
+  dataset_id   = powerbi_pbix.example.dataset_id
+  gateway_id   = data.powerbi_dataset_discover_gateways.example.gateways[0].id
+}
@@ -0,0 +1,13 @@
+data "powerbi_datasets" "models" {
+  workspace_id = powerbi_workspace.example.id
+  name_regex   = "Model$"
+}
+
+resource "powerbi_refresh_schedule" "models" {
+  count        = length(data.powerbi_datasets.models.datasets)
+  workspace_id = powerbi_workspace.example.id
+  dataset_id   = data.powerbi_datasets.models.datasets[count.index].id
+  enabled      = true
+  days         = ["Monday", "Wednesday", "Friday"]
+  times        = ["06:00"]
+}
@@ -0,0 +1,6 @@
+data "powerbi_embed_token" "example" {
+  workspace_id = powerbi_workspace.example.id
+  type         = "report"
+  resource_id  = powerbi_pbix.example.report_id
+  access_level = "View"
+}
@@ -0,0 +1,7 @@
+data "powerbi_gateway" "existing" {
+  id = "gateway-12345-abcde-67890-fghij"
+}
+
+output "gateway_machine" {
+  value = data.powerbi_gateway.existing.gateway_machine
+}
@@ -0,0 +1,11 @@
+data "powerbi_gateway" "enterprise" {
+  name = "Enterprise Gateway"
+}
+
+output "gateway_status" {
+  value = data.powerbi_gateway.enterprise.gateway_status
+}
+
+output "gateway_version" {
+  value = data.powerbi_gateway.enterprise.gateway_version
+}
@@ -0,0 +1,14 @@
+data "powerbi_gateway" "corp_gateway" {
+  name = "Corporate Gateway"
+}
+
+resource "powerbi_gateway_datasource" "sql_server" {
+  gateway_id      = data.powerbi_gateway.corp_gateway.id
+  datasource_name = "Production SQL Server"
+  datasource_type = "Sql"
+
+  connection_details {
+    server   = "sql.company.com"
+    database = "ProductionDB"
+  }
+}
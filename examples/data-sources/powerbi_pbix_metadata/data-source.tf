@@ -0,0 +1,20 @@
+data "powerbi_pbix_metadata" "sales" {
+  source = "./sales.pbix"
+}
+
+resource "powerbi_pbix" "sales" {
+  workspace_id = powerbi_workspace.example.id
+  name         = "Sales"
+  source       = "./sales.pbix"
+
+  lifecycle {
+    precondition {
+      condition     = data.powerbi_pbix_metadata.sales.connection_type == "Live"
+      error_message = "Sales report must be live connected to the shared dataset."
+    }
+  }
+}
+
+output "sales_pages" {
+  value = data.powerbi_pbix_metadata.sales.pages[*].display_name
+}
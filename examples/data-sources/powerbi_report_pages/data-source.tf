@@ -0,0 +1,15 @@
+data "powerbi_report_pages" "example" {
+  workspace_id = powerbi_workspace.example.id
+  report_id    = powerbi_pbix.example.report_id
+}
+
+resource "powerbi_report_export" "first_page" {
+  workspace_id = powerbi_workspace.example.id
+  report_id    = powerbi_pbix.example.report_id
+  format       = "PNG"
+  output_path  = "./first_page.png"
+
+  page {
+    name = data.powerbi_report_pages.example.pages[0].name
+  }
+}
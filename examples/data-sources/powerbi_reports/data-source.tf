@@ -0,0 +1,9 @@
+data "powerbi_reports" "sales" {
+  workspace_id = powerbi_workspace.example.id
+  name_regex   = "^Sales"
+  dataset_id   = powerbi_pbix.model.dataset_id
+}
+
+output "sales_report_urls" {
+  value = data.powerbi_reports.sales.reports[*].web_url
+}
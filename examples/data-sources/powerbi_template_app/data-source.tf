@@ -0,0 +1,3 @@
+data "powerbi_template_app" "example" {
+  name = "Sales Template"
+}
@@ -0,0 +1,7 @@
+data "powerbi_workspace" "myworkspace" {
+  name = "Sample workspace"
+}
+
+output myworkspace_id {
+  value = data.powerbi_workspace.myworkspace.id
+}
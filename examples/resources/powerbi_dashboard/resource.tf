@@ -0,0 +1,8 @@
+resource "powerbi_workspace" "example" {
+  name = "Example Workspace"
+}
+
+resource "powerbi_dashboard" "sales" {
+  name         = "Sales Dashboard"
+  workspace_id = powerbi_workspace.example.id
+}
@@ -0,0 +1,10 @@
+resource "powerbi_dashboard_tile" "rebounded_tile" {
+  workspace_id             = powerbi_workspace.source.id
+  dashboard_id             = powerbi_dashboard.target_dashboard.id
+  source_dashboard_id      = powerbi_dashboard.source_dashboard.id
+  source_tile_id           = "tile-12345"
+  target_workspace_id      = powerbi_workspace.target.id
+  target_report_id         = "new-report-id"
+  target_model_id          = "new-model-id"
+  position_conflict_action = "Abort"
+}
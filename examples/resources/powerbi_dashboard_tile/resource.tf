@@ -0,0 +1,26 @@
+resource "powerbi_workspace" "source" {
+  name = "Source Workspace"
+}
+
+resource "powerbi_workspace" "target" {
+  name = "Target Workspace"
+}
+
+resource "powerbi_dashboard" "source_dashboard" {
+  name         = "Source Dashboard"
+  workspace_id = powerbi_workspace.source.id
+}
+
+resource "powerbi_dashboard" "target_dashboard" {
+  name         = "Target Dashboard"
+  workspace_id = powerbi_workspace.target.id
+}
+
+resource "powerbi_dashboard_tile" "cloned_tile" {
+  workspace_id             = powerbi_workspace.source.id
+  dashboard_id             = powerbi_dashboard.target_dashboard.id
+  source_dashboard_id      = powerbi_dashboard.source_dashboard.id
+  source_tile_id           = "tile-12345"
+  target_workspace_id      = powerbi_workspace.target.id
+  position_conflict_action = "Tail"
+}
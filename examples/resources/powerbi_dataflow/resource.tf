@@ -0,0 +1,10 @@
+resource "powerbi_workspace" "example" {
+  name = "Example Workspace"
+}
+
+resource "powerbi_dataflow" "example" {
+  workspace_id = powerbi_workspace.example.id
+  name         = "Staging"
+  description  = "Loads raw sales data"
+  definition   = file("${path.module}/staging.json")
+}
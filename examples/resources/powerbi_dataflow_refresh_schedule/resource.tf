@@ -0,0 +1,9 @@
+resource "powerbi_dataflow_refresh_schedule" "example" {
+  workspace_id       = powerbi_workspace.example.id
+  dataflow_id        = powerbi_dataflow.example.id
+  enabled            = true
+  days               = ["Monday", "Wednesday", "Friday"]
+  times              = ["06:00", "18:00"]
+  local_time_zone_id = "UTC"
+  notify_option      = "MailOnFailure"
+}
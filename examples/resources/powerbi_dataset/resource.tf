@@ -0,0 +1,37 @@
+resource "powerbi_workspace" "test" {
+  name = "Entries Workspace"
+}
+resource "powerbi_dataset" "test" {
+  workspace_id = powerbi_workspace.test.id
+  default_mode = "push"
+  name = "Entries Dataset"
+
+  table {
+    name = "entries"
+    column {
+      name = "entryId"
+      data_type = "string"
+    }
+  }
+
+  table {
+    name = "entries-audit"
+    column {
+      name = "entryId"
+      data_type = "string"
+    }
+    column {
+      name = "modifiedBy"
+      data_type = "string"
+    }
+  }
+
+  relationship {
+    name = "entries to entires-audit"
+    from_table = "entries"
+    from_column = "entryId"
+    to_table = "entries-audit"
+    to_column = "entryId"
+    cross_filtering_behavior = "automatic"
+  }
+}
@@ -0,0 +1,12 @@
+resource "powerbi_dataset_datasource_credentials" "azure_sql" {
+  workspace_id    = powerbi_workspace.example.id
+  dataset_id      = powerbi_pbix.example.dataset_id
+  datasource_type = "Sql"
+  server          = "example.database.windows.net"
+  database        = "sales"
+
+  credential_type = "Basic"
+  username        = "reporting"
+  password        = var.reporting_password
+  privacy_level   = "Organizational"
+}
@@ -0,0 +1,12 @@
+resource "powerbi_dataset_datasources" "example" {
+  workspace_id = powerbi_workspace.example.id
+  dataset_id   = "c3ad1d8a-0a6c-4d5a-9b6c-2b3a9c8f1e42"
+
+  datasource {
+    type              = "Sql"
+    server            = "sql-prod.contoso.com"
+    database          = "Sales"
+    original_server   = "sql-dev.contoso.com"
+    original_database = "SalesDev"
+  }
+}
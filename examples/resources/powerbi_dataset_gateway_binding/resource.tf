@@ -0,0 +1,21 @@
+data "powerbi_gateway" "enterprise" {
+  name = "Enterprise Gateway"
+}
+
+resource "powerbi_gateway_datasource" "sql_server" {
+  gateway_id      = data.powerbi_gateway.enterprise.id
+  datasource_name = "Production SQL Server"
+  datasource_type = "Sql"
+
+  connection_details {
+    server   = "sql.company.com"
+    database = "ProductionDB"
+  }
+}
+
+resource "powerbi_dataset_gateway_binding" "example" {
+  workspace_id   = powerbi_workspace.example.id
+  dataset_id     = powerbi_pbix.example.dataset_id
+  gateway_id     = data.powerbi_gateway.enterprise.id
+  datasource_ids = [powerbi_gateway_datasource.sql_server.id]
+}
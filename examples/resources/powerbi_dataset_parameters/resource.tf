@@ -0,0 +1,14 @@
+resource "powerbi_dataset_parameters" "example" {
+  workspace_id = powerbi_workspace.example.id
+  dataset_id   = "c3ad1d8a-0a6c-4d5a-9b6c-2b3a9c8f1e42"
+  take_over    = true
+
+  parameter {
+    name  = "ServerName"
+    value = "sql-prod.contoso.com"
+  }
+  parameter {
+    name  = "DatabaseName"
+    value = "Sales"
+  }
+}
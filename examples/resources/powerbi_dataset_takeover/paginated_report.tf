@@ -0,0 +1,4 @@
+resource "powerbi_dataset_takeover" "paginated" {
+  workspace_id        = powerbi_workspace.example.id
+  paginated_report_id = "5b1e7c3d-8a4f-4e2b-9c6d-1f0a3b2c4d5e"
+}
@@ -0,0 +1,4 @@
+resource "powerbi_deployment_pipeline" "example" {
+  display_name = "Sales pipeline"
+  description  = "Deploys sales reports from development to production"
+}
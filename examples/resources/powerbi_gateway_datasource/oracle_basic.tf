@@ -0,0 +1,18 @@
+resource "powerbi_gateway_datasource" "oracle" {
+  gateway_id      = data.powerbi_gateway.enterprise.id
+  datasource_name = "Oracle DW"
+  datasource_type = "Oracle"
+
+  connection_details {
+    server   = "oracle.company.com:1521"
+    database = "DWPROD"
+  }
+
+  credential_type = "Basic"
+
+  credential_details {
+    credentials          = "encrypted_credentials_string"
+    encryption_algorithm = "RSA-OAEP"
+    privacy_level        = "Organizational"
+  }
+}
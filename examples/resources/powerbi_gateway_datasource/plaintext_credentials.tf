@@ -0,0 +1,19 @@
+resource "powerbi_gateway_datasource" "sql_basic" {
+  gateway_id      = data.powerbi_gateway.enterprise.id
+  datasource_name = "Reporting SQL Server"
+  datasource_type = "Sql"
+
+  connection_details {
+    server   = "sql.company.com"
+    database = "ReportingDB"
+  }
+
+  credential_type = "Basic"
+
+  credential_details {
+    username             = "reporting"
+    password             = var.reporting_password
+    encrypted_connection = "Encrypted"
+    privacy_level        = "Organizational"
+  }
+}
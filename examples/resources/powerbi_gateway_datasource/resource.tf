@@ -0,0 +1,21 @@
+data "powerbi_gateway" "enterprise" {
+  name = "Enterprise Gateway"
+}
+
+resource "powerbi_gateway_datasource" "sql_server" {
+  gateway_id      = data.powerbi_gateway.enterprise.id
+  datasource_name = "Production SQL Server"
+  datasource_type = "Sql"
+
+  connection_details {
+    server   = "sql.company.com"
+    database = "ProductionDB"
+  }
+
+  credential_type = "Windows"
+
+  credential_details {
+    privacy_level           = "Organizational"
+    use_caller_aad_identity = true
+  }
+}
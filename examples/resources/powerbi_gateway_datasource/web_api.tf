@@ -0,0 +1,15 @@
+resource "powerbi_gateway_datasource" "web_api" {
+  gateway_id      = data.powerbi_gateway.enterprise.id
+  datasource_name = "External API"
+  datasource_type = "Web"
+
+  connection_details {
+    url = "https://api.example.com/v1"
+  }
+
+  credential_type = "OAuth2"
+
+  credential_details {
+    privacy_level = "Public"
+  }
+}
@@ -0,0 +1,8 @@
+resource "powerbi_gateway_datasource_user" "analysts_group" {
+  gateway_id              = data.powerbi_gateway.enterprise.id
+  datasource_id           = powerbi_gateway_datasource.sql_server.id
+  identifier              = "analysts@company.com"
+  datasource_access_right = "ReadOverrideEffectiveIdentity"
+  principal_type          = "Group"
+  display_name            = "Data Analysts Group"
+}
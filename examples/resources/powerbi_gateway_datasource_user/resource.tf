@@ -0,0 +1,22 @@
+data "powerbi_gateway" "enterprise" {
+  name = "Enterprise Gateway"
+}
+
+resource "powerbi_gateway_datasource" "sql_server" {
+  gateway_id      = data.powerbi_gateway.enterprise.id
+  datasource_name = "Production SQL Server"
+  datasource_type = "Sql"
+
+  connection_details {
+    server   = "sql.company.com"
+    database = "ProductionDB"
+  }
+}
+
+resource "powerbi_gateway_datasource_user" "analyst" {
+  gateway_id              = data.powerbi_gateway.enterprise.id
+  datasource_id           = powerbi_gateway_datasource.sql_server.id
+  email_address           = "data.analyst@company.com"
+  datasource_access_right = "Read"
+  principal_type          = "User"
+}
@@ -0,0 +1,7 @@
+resource "powerbi_gateway_datasource_user" "service_app" {
+  gateway_id              = data.powerbi_gateway.enterprise.id
+  datasource_id           = powerbi_gateway_datasource.sql_server.id
+  graph_id                = "12345678-1234-1234-1234-123456789abc"
+  datasource_access_right = "Read"
+  principal_type          = "App"
+}
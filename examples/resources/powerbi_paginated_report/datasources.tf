@@ -0,0 +1,12 @@
+resource "powerbi_paginated_report" "invoice" {
+  workspace_id = powerbi_workspace.sales.id
+  name         = "Invoice"
+  source       = "./invoice.rdl"
+  source_hash  = filemd5("./invoice.rdl")
+
+  datasource {
+    name     = "SalesDataSource"
+    server   = var.sql_server
+    database = var.sql_database
+  }
+}
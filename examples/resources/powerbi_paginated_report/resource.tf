@@ -0,0 +1,11 @@
+resource "powerbi_paginated_report" "invoice" {
+  workspace_id = powerbi_workspace.sales.id
+  name         = "Invoice"
+  source       = "./invoice.rdl"
+  source_hash  = filemd5("./invoice.rdl")
+
+  connection_string {
+    datasource_name = "SalesDataSource"
+    value           = "Data Source=${var.sql_server};Initial Catalog=${var.sql_database}"
+  }
+}
@@ -0,0 +1,5 @@
+resource "powerbi_pbip" "sales" {
+  workspace_id = "470b0d57-1f23-4332-a16f-9235bd174318"
+  name         = "Sales"
+  source       = abspath("./reports/Sales")
+}
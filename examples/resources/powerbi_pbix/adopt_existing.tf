@@ -0,0 +1,6 @@
+resource "powerbi_pbix" "mypbix" {
+  workspace_id   = "470b0d57-1f23-4332-a16f-9235bd174318"
+  name           = "My PBIX"
+  source         = "./my-pbix.pbix"
+  adopt_existing = true
+}
@@ -0,0 +1,13 @@
+resource "powerbi_pbix" "mypbix" {
+  workspace_id = "470b0d57-1f23-4332-a16f-9235bd174318"
+  name         = "My PBIX"
+  source       = "./my-pbix.pbix"
+  mashup_parameter {
+    name  = "Environment"
+    value = "Production"
+  }
+  mashup_replacement {
+    original = "dev-sql.database.windows.net"
+    value    = "prod-sql.database.windows.net"
+  }
+}
@@ -0,0 +1,6 @@
+resource "powerbi_pbix" "mypbix" {
+  my_workspace  = true
+  name          = "My PBIX"
+  source        = "./my-pbix.pbix"
+  name_conflict = "Abort"
+}
@@ -0,0 +1,14 @@
+resource "powerbi_pbix" "mypbix" {
+  workspace_id = "470b0d57-1f23-4332-a16f-9235bd174318"
+  name         = "My PBIX"
+  source       = "./my-pbix.pbix"
+  source_hash  = filemd5("./my-pbix.pbix")
+  parameter {
+    name  = "UrlParam"
+    value = "https://test-data.com/source"
+  }
+  parameter {
+    name  = "Filter"
+    value = "Blue"
+  }
+}
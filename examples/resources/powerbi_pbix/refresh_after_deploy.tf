@@ -0,0 +1,12 @@
+resource "powerbi_pbix" "mypbix" {
+  workspace_id         = "470b0d57-1f23-4332-a16f-9235bd174318"
+  name                 = "My PBIX"
+  source               = "./my-pbix.pbix"
+  refresh_after_deploy = true
+  wait_for_refresh     = true
+  smoke_test_query     = "EVALUATE ROW(\"Rows\", COUNTROWS('Sales'))"
+  parameter {
+    name  = "Environment"
+    value = "Production"
+  }
+}
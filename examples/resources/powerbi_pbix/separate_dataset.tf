@@ -0,0 +1,23 @@
+resource "powerbi_workspace" "example" {
+  name = "Example Workspace"
+}
+
+# Deploy the dataset first ...
+resource "powerbi_pbix" "example_dataset" {
+  workspace_id = powerbi_workspace.example.id
+  name         = "My dataset"
+  source       = "data/Datasets/Dataset.pbix"
+  source_hash  = filemd5("data/Datasets/Dataset.pbix")
+  skip_report  = true # Only deploy the dataset
+}
+
+# ... and then connect it to a report
+resource "powerbi_pbix" "example_report" {
+  workspace_id      = powerbi_workspace.example.id
+  name              = "My report"
+  source            = "data/Reports/Report.pbix"
+  source_hash       = filemd5("data/Reports/Report.pbix")
+  rebind_dataset_id = powerbi_pbix.example_dataset.dataset_id # Bind the report to the dataset
+}
+
+# add more reports here and bind them to the same dataset
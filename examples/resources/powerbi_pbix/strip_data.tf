@@ -0,0 +1,9 @@
+resource "powerbi_pbix" "mypbix" {
+  workspace_id         = "470b0d57-1f23-4332-a16f-9235bd174318"
+  name                 = "My PBIX"
+  source               = "./my-pbix.pbix"
+  strip_data           = true
+  strip_data_template  = "./my-pbix.pbit"
+  refresh_after_deploy = true
+  wait_for_refresh     = true
+}
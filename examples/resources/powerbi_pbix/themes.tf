@@ -0,0 +1,7 @@
+resource "powerbi_pbix" "mypbix" {
+  workspace_id = "470b0d57-1f23-4332-a16f-9235bd174318"
+  name         = "My PBIX"
+  source       = "./my-pbix.pbix"
+  theme_file   = "./corporate-theme.json"
+  hidden_pages = ["Tooltip", "Drillthrough Details"]
+}
@@ -0,0 +1,20 @@
+resource "powerbi_pipeline_operation" "example" {
+  pipeline_id        = powerbi_deployment_pipeline.example.id
+  source_stage_order = 0
+  note               = "Deploy sales report"
+
+  artifacts_to_deploy {
+    artifact_id   = powerbi_pbix.example.report_id
+    artifact_type = "Report"
+  }
+
+  options {
+    allow_create_artifact    = true
+    allow_overwrite_artifact = true
+  }
+
+  depends_on = [
+    powerbi_pipeline_stage.development,
+    powerbi_pipeline_stage.test,
+  ]
+}
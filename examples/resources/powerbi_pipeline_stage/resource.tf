@@ -0,0 +1,11 @@
+resource "powerbi_pipeline_stage" "development" {
+  pipeline_id  = powerbi_deployment_pipeline.example.id
+  stage_order  = 0
+  workspace_id = powerbi_workspace.development.id
+}
+
+resource "powerbi_pipeline_stage" "test" {
+  pipeline_id  = powerbi_deployment_pipeline.example.id
+  stage_order  = 1
+  workspace_id = powerbi_workspace.test.id
+}
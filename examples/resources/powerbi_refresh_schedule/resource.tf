@@ -0,0 +1,9 @@
+resource "powerbi_refresh_schedule" "test" {
+  workspace_id       = powerbi_workspace.myworkspace.id
+  dataset_id         = powerbi_pbix.test.dataset_id
+  enabled            = true
+  days               = ["Monday", "Wednesday", "Friday"]
+  times              = ["09:00", "17:30"]
+  local_time_zone_id = "Pacific Standard Time"
+  notify_option      = "MailOnFailure"
+}
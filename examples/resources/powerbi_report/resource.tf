@@ -0,0 +1,23 @@
+resource "powerbi_pbix" "model" {
+  workspace_id = powerbi_workspace.models.id
+  name         = "Sales Model"
+  source       = "./sales_model.pbix"
+  source_hash  = filemd5("./sales_model.pbix")
+  skip_report  = true
+}
+
+resource "powerbi_pbix" "template" {
+  workspace_id = powerbi_workspace.development.id
+  name         = "Sales Report"
+  source       = "./sales_report.pbix"
+  source_hash  = filemd5("./sales_report.pbix")
+}
+
+resource "powerbi_report" "sales" {
+  workspace_id        = powerbi_workspace.sales.id
+  name                = "Sales"
+  source_workspace_id = powerbi_workspace.development.id
+  source_report_id    = powerbi_pbix.template.report_id
+  source_hash         = filemd5("./sales_report.pbix")
+  dataset_id          = powerbi_pbix.model.dataset_id
+}
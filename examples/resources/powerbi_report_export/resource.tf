@@ -0,0 +1,22 @@
+resource "powerbi_report_export" "monthly" {
+  workspace_id = powerbi_workspace.example.id
+  report_id    = powerbi_pbix.example.report_id
+  format       = "PDF"
+  output_path  = "./archive/sales-${var.month}.pdf"
+
+  page {
+    name = "ReportSection"
+  }
+
+  filters = ["Store/Territory eq 'NC'"]
+
+  identity {
+    username    = "user@example.com"
+    roles       = ["Territory Manager"]
+    dataset_ids = [powerbi_pbix.example.dataset_id]
+  }
+
+  triggers = {
+    month = var.month
+  }
+}
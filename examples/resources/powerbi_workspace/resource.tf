@@ -0,0 +1,3 @@
+resource "powerbi_workspace" "myworkspace" {
+  name = "Sample workspace"
+}
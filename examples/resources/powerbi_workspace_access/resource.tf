@@ -0,0 +1,13 @@
+resource "powerbi_workspace_access" "allow_email_address" {
+  workspace_id            = "470b0d57-1f23-4332-a16f-9235bd174318"
+  group_user_access_right = "Member"
+  email_address           = "powerbiuser@mycompany.com"
+  principal_type          = "User"
+}
+
+resource "powerbi_workspace_access" "allow_azure_app" {
+  workspace_id            = "470b0d57-1f23-4332-a16f-9235bd174318"
+  group_user_access_right = "Admin"
+  principal_type          = "App"
+  identifier              = "1f69e798-5852-4fdd-ab01-33bb14b6e934
+}
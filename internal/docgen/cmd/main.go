@@ -1,10 +1,36 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"os"
+
 	"github.com/codecutout/terraform-provider-powerbi/internal/docgen"
 	"github.com/codecutout/terraform-provider-powerbi/internal/powerbi"
 )
 
 func main() {
-	docgen.PopulateTerraformDocs("./docs", "powerbi", powerbi.Provider())
+	check := flag.Bool("check", false, "report documents that differ from the generated documentation instead of updating them")
+	flag.Parse()
+
+	if *check {
+		outdatedPaths, err := docgen.CheckTerraformDocs("./docs", "powerbi", powerbi.Provider())
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		for _, outdatedPath := range outdatedPaths {
+			fmt.Println(outdatedPath)
+		}
+		if len(outdatedPaths) > 0 {
+			fmt.Fprintln(os.Stderr, "documentation is out of date, run go run internal/docgen/cmd/main.go to update it")
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := docgen.PopulateTerraformDocs("./docs", "powerbi", powerbi.Provider()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 }
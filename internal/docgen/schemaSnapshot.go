@@ -0,0 +1,214 @@
+package docgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// SchemaSnapshot represents the schemas of a provider in a form that can be saved as JSON and compared between versions
+type SchemaSnapshot struct {
+	Provider    *SchemaBlockSnapshot            `json:"provider"`
+	Resources   map[string]*SchemaBlockSnapshot `json:"resources"`
+	DataSources map[string]*SchemaBlockSnapshot `json:"data_sources"`
+}
+
+// SchemaBlockSnapshot represents the attributes of a resource, data source or nested block
+type SchemaBlockSnapshot struct {
+	Attributes map[string]*SchemaAttributeSnapshot `json:"attributes"`
+}
+
+// SchemaAttributeSnapshot represents the parts of an attribute schema that affect configurations using it
+type SchemaAttributeSnapshot struct {
+	Type     string               `json:"type"`
+	ElemType string               `json:"elem_type,omitempty"`
+	Required bool                 `json:"required,omitempty"`
+	Optional bool                 `json:"optional,omitempty"`
+	Computed bool                 `json:"computed,omitempty"`
+	ForceNew bool                 `json:"force_new,omitempty"`
+	Default  interface{}          `json:"default,omitempty"`
+	Block    *SchemaBlockSnapshot `json:"block,omitempty"`
+}
+
+// SchemaChange represents a breaking change between two schema snapshots
+type SchemaChange struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Attribute string `json:"attribute,omitempty"`
+	Message   string `json:"message"`
+}
+
+// String returns a single line description of the change
+func (change SchemaChange) String() string {
+	if change.Attribute == "" {
+		return fmt.Sprintf("%s %s: %s", change.Kind, change.Name, change.Message)
+	}
+	return fmt.Sprintf("%s %s: attribute %s %s", change.Kind, change.Name, change.Attribute, change.Message)
+}
+
+// NewSchemaSnapshot returns a snapshot of the schemas of the provider, its resources and its data sources
+func NewSchemaSnapshot(provider *schema.Provider) *SchemaSnapshot {
+	snapshot := &SchemaSnapshot{
+		Provider:    newSchemaBlockSnapshot(provider.Schema),
+		Resources:   make(map[string]*SchemaBlockSnapshot),
+		DataSources: make(map[string]*SchemaBlockSnapshot),
+	}
+	for name, resource := range provider.ResourcesMap {
+		snapshot.Resources[name] = newSchemaBlockSnapshot(resource.Schema)
+	}
+	for name, dataSource := range provider.DataSourcesMap {
+		snapshot.DataSources[name] = newSchemaBlockSnapshot(dataSource.Schema)
+	}
+	return snapshot
+}
+
+func newSchemaBlockSnapshot(propertySchemas map[string]*schema.Schema) *SchemaBlockSnapshot {
+	block := &SchemaBlockSnapshot{Attributes: make(map[string]*SchemaAttributeSnapshot)}
+	for _, prop := range sortProperties(propertySchemas) {
+		attribute := &SchemaAttributeSnapshot{
+			Type:     schemaTypeName(prop.Schema.Type),
+			Required: prop.Schema.Required,
+			Optional: prop.Schema.Optional,
+			Computed: prop.Schema.Computed,
+			ForceNew: prop.Schema.ForceNew,
+			Default:  prop.Schema.Default,
+		}
+		switch elem := prop.Schema.Elem.(type) {
+		case *schema.Resource:
+			attribute.Block = newSchemaBlockSnapshot(elem.Schema)
+		case *schema.Schema:
+			attribute.ElemType = schemaTypeName(elem.Type)
+		}
+		block.Attributes[prop.Name] = attribute
+	}
+	return block
+}
+
+// schemaTypeName returns the lower case name of the type, e.g. string for TypeString
+func schemaTypeName(valueType schema.ValueType) string {
+	return strings.ToLower(strings.TrimPrefix(valueType.String(), "Type"))
+}
+
+// CompareSchemaSnapshots returns the changes between previous and current that can break existing configurations.
+// Removed resources, data sources and attributes, type changes, attributes that now force a new resource, attributes
+// that are now required and changed defaults are reported
+func CompareSchemaSnapshots(previous *SchemaSnapshot, current *SchemaSnapshot) []SchemaChange {
+	changes := []SchemaChange{}
+	changes = append(changes, compareSchemaBlocks("provider", "powerbi", "", previous.Provider, current.Provider)...)
+	changes = append(changes, compareSchemaBlockMaps("resource", previous.Resources, current.Resources)...)
+	changes = append(changes, compareSchemaBlockMaps("data source", previous.DataSources, current.DataSources)...)
+	return changes
+}
+
+func compareSchemaBlockMaps(kind string, previous map[string]*SchemaBlockSnapshot, current map[string]*SchemaBlockSnapshot) []SchemaChange {
+	changes := []SchemaChange{}
+	for _, name := range sortedBlockNames(previous) {
+		currentBlock, ok := current[name]
+		if !ok {
+			changes = append(changes, SchemaChange{Kind: kind, Name: name, Message: "was removed"})
+			continue
+		}
+		changes = append(changes, compareSchemaBlocks(kind, name, "", previous[name], currentBlock)...)
+	}
+	return changes
+}
+
+func compareSchemaBlocks(kind string, name string, parentPath string, previous *SchemaBlockSnapshot, current *SchemaBlockSnapshot) []SchemaChange {
+	changes := []SchemaChange{}
+	if previous == nil || current == nil {
+		return changes
+	}
+
+	newChange := func(path string, format string, args ...interface{}) {
+		changes = append(changes, SchemaChange{Kind: kind, Name: name, Attribute: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for _, attributeName := range sortedAttributeNames(previous.Attributes) {
+		path := attributeName
+		if parentPath != "" {
+			path = parentPath + "." + attributeName
+		}
+		previousAttribute := previous.Attributes[attributeName]
+		currentAttribute, ok := current.Attributes[attributeName]
+		if !ok {
+			newChange(path, "was removed")
+			continue
+		}
+
+		previousType := schemaAttributeTypeName(previousAttribute)
+		currentType := schemaAttributeTypeName(currentAttribute)
+		if previousType != currentType {
+			newChange(path, "changed type from %s to %s", previousType, currentType)
+			continue
+		}
+		if !previousAttribute.Required && currentAttribute.Required {
+			newChange(path, "changed from optional to required")
+		}
+		if (previousAttribute.Required || previousAttribute.Optional) && !currentAttribute.Required && !currentAttribute.Optional {
+			newChange(path, "can no longer be set")
+		}
+		if !previousAttribute.ForceNew && currentAttribute.ForceNew {
+			newChange(path, "now forces a new resource")
+		}
+		previousDefault, currentDefault := schemaDefaultString(previousAttribute.Default), schemaDefaultString(currentAttribute.Default)
+		if previousDefault != currentDefault {
+			newChange(path, "changed default from %s to %s", previousDefault, currentDefault)
+		}
+		changes = append(changes, compareSchemaBlocks(kind, name, path, previousAttribute.Block, currentAttribute.Block)...)
+	}
+
+	for _, attributeName := range sortedAttributeNames(current.Attributes) {
+		if _, ok := previous.Attributes[attributeName]; !ok && current.Attributes[attributeName].Required {
+			path := attributeName
+			if parentPath != "" {
+				path = parentPath + "." + attributeName
+			}
+			newChange(path, "was added as a required attribute")
+		}
+	}
+	return changes
+}
+
+// schemaAttributeTypeName returns the type including the element type, e.g. list(string)
+func schemaAttributeTypeName(attribute *SchemaAttributeSnapshot) string {
+	if attribute.Block != nil {
+		return attribute.Type + "(block)"
+	}
+	if attribute.ElemType != "" {
+		return attribute.Type + "(" + attribute.ElemType + ")"
+	}
+	return attribute.Type
+}
+
+// schemaDefaultString returns the default as JSON so defaults read from a snapshot file compare equal to defaults in code
+func schemaDefaultString(value interface{}) string {
+	if value == nil {
+		return "none"
+	}
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(bytes)
+}
+
+func sortedBlockNames(blocks map[string]*SchemaBlockSnapshot) []string {
+	names := make([]string, 0, len(blocks))
+	for name := range blocks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedAttributeNames(attributes map[string]*SchemaAttributeSnapshot) []string {
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
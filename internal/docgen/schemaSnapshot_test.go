@@ -0,0 +1,62 @@
+package docgen
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestCompareSchemaSnapshots(t *testing.T) {
+	previousProvider := testProvider()
+	previousProvider.ResourcesMap["test_table"].Schema["retention_days"] = &schema.Schema{Type: schema.TypeInt, Optional: true, Default: 7}
+	previousProvider.ResourcesMap["test_table"].Schema["owner"] = &schema.Schema{Type: schema.TypeString, Optional: true}
+	previousProvider.ResourcesMap["test_view"] = &schema.Resource{Schema: map[string]*schema.Schema{}}
+
+	// snapshots are compared after being saved, so defaults read back as JSON numbers
+	previousBytes, err := json.Marshal(NewSchemaSnapshot(previousProvider))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var previous SchemaSnapshot
+	if err := json.Unmarshal(previousBytes, &previous); err != nil {
+		t.Fatal(err)
+	}
+
+	currentProvider := testProvider()
+	currentSchema := currentProvider.ResourcesMap["test_table"].Schema
+	currentSchema["retention_days"] = &schema.Schema{Type: schema.TypeInt, Optional: true, Default: 30}
+	currentSchema["owner"] = &schema.Schema{Type: schema.TypeString, Required: true, ForceNew: true}
+	currentSchema["row_count"] = &schema.Schema{Type: schema.TypeString, Computed: true}
+	currentSchema["column"].Elem.(*schema.Resource).Schema["type"] = &schema.Schema{Type: schema.TypeString, Required: true}
+	delete(currentSchema["column"].Elem.(*schema.Resource).Schema["format"].Elem.(*schema.Resource).Schema, "pattern")
+	currentProvider.DataSourcesMap["test_table"].Schema["id"].Optional = false
+
+	changes := CompareSchemaSnapshots(&previous, NewSchemaSnapshot(currentProvider))
+
+	actual := []string{}
+	for _, change := range changes {
+		actual = append(actual, change.String())
+	}
+	expected := []string{
+		"resource test_table: attribute column.format.pattern was removed",
+		"resource test_table: attribute column.type was added as a required attribute",
+		"resource test_table: attribute owner changed from optional to required",
+		"resource test_table: attribute owner now forces a new resource",
+		"resource test_table: attribute retention_days changed default from 7 to 30",
+		"resource test_table: attribute row_count changed type from int to string",
+		"resource test_view: was removed",
+		"data source test_table: attribute id can no longer be set",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected changes\n%v\ngot\n%v", expected, actual)
+	}
+}
+
+func TestCompareSchemaSnapshots_noChanges(t *testing.T) {
+	changes := CompareSchemaSnapshots(NewSchemaSnapshot(testProvider()), NewSchemaSnapshot(testProvider()))
+	if len(changes) != 0 {
+		t.Errorf("expected no changes, got %v", changes)
+	}
+}
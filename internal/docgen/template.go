@@ -2,36 +2,36 @@ package docgen
 
 import (
 	"fmt"
-	"io/ioutil"
 	"regexp"
+	"strings"
 )
 
-func populateTemplate(filename string, model interface{}) error {
-	template, err := ioutil.ReadFile(filename)
+// renderTemplate replaces the content between docgen markers with the field of the model named by the marker.
+// Markers for map fields name the key of the value to use, e.g. <!-- docgen:Examples:import -->
+func renderTemplate(templateString string, model interface{}) (string, error) {
+	re, err := regexp.Compile(`(?s)<!--\s*docgen:([a-zA-Z][a-zA-Z0-9]*)(:[a-zA-Z0-9_]+)?\s*-->(.*?)<!--\s*/docgen\s*-->`)
 	if err != nil {
-		return err
-	}
-
-	templateString := string(template)
-	re, err := regexp.Compile(`(?s)<!--\s*docgen:([a-zA-Z][a-zA-Z0-9]*)\s*-->(.*?)<!--\s*/docgen\s*-->`)
-	if err != nil {
-		return err
+		return "", err
 	}
 
 	modelMap, err := toMap(model)
 	if err != nil {
-		return err
+		return "", err
 	}
 	newString := re.ReplaceAllStringFunc(templateString, func(match string) string {
-		modelProp := re.FindStringSubmatch(match)[1]
+		submatches := re.FindStringSubmatch(match)
+		modelProp, modelKey := submatches[1], submatches[2]
 		val, ok := modelMap[modelProp]
+		if valMap, isMap := val.(map[string]string); isMap {
+			val, ok = valMap[strings.TrimPrefix(modelKey, ":")]
+		}
 		if ok {
-			return fmt.Sprintf(`<!-- docgen:%s -->
+			return fmt.Sprintf(`<!-- docgen:%s%s -->
 %v
-<!-- /docgen -->`, modelProp, val)
+<!-- /docgen -->`, modelProp, modelKey, val)
 		}
-		return fmt.Sprintf(`<!-- docgen:%s -->
-<!-- /docgen -->`, modelProp)
+		return fmt.Sprintf(`<!-- docgen:%s%s -->
+<!-- /docgen -->`, modelProp, modelKey)
 	})
-	return writeToFile(filename, newString)
+	return newString, nil
 }
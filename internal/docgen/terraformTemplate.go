@@ -2,6 +2,7 @@ package docgen
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"sort"
@@ -17,99 +18,248 @@ type property struct {
 
 type nestedObjectProperty struct {
 	HeaderText   string
-	Name         string
+	Path         string
 	NestedSchema map[string]*schema.Schema
 }
 
-// PopulateTerraformDocs update template fields inline in files in the folderpath
+// generatedDoc represents a documentation file and the content generated for it
+type generatedDoc struct {
+	Path     string
+	Exists   bool
+	Existing string
+	Content  string
+}
+
+// PopulateTerraformDocs update template fields inline in files in the folderpath. Resources and
+// data sources without a document have one created in the layout of the Terraform registry.
+// Examples are read from the examples folder alongside folderpath, using the same layout as the
+// registry, e.g. examples/resources/powerbi_workspace/resource.tf. Further examples in the same folder are
+// referenced by file name, e.g. <!-- docgen:Examples:import --> for examples/resources/powerbi_workspace/import.tf
 func PopulateTerraformDocs(folderpath string, providerName string, provider *schema.Provider) error {
+	docs, err := generateTerraformDocs(folderpath, providerName, provider)
+	if err != nil {
+		return err
+	}
+
+	for _, doc := range docs {
+		if doc.Exists && doc.Content == doc.Existing {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(doc.Path), 0755); err != nil {
+			return err
+		}
+		if err := writeToFile(doc.Path, doc.Content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckTerraformDocs returns the paths of documents in folderpath that are missing or differ from
+// the documents PopulateTerraformDocs would generate
+func CheckTerraformDocs(folderpath string, providerName string, provider *schema.Provider) ([]string, error) {
+	docs, err := generateTerraformDocs(folderpath, providerName, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	outdatedPaths := []string{}
+	for _, doc := range docs {
+		if !doc.Exists || doc.Content != doc.Existing {
+			outdatedPaths = append(outdatedPaths, doc.Path)
+		}
+	}
+	return outdatedPaths, nil
+}
+
+func generateTerraformDocs(folderpath string, providerName string, provider *schema.Provider) ([]generatedDoc, error) {
+	examplesPath := filepath.Join(filepath.Dir(filepath.Clean(folderpath)), "examples")
+	docs := []generatedDoc{}
 
 	// populate index documents
 	indexMatches, err := filepath.Glob(filepath.Join(folderpath, "index.*"))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	for _, indexMatch := range indexMatches {
-		populateProviderDoc(indexMatch, provider)
+		example, err := readExample(filepath.Join(examplesPath, "provider", "provider.tf"))
+		if err != nil {
+			return nil, err
+		}
+		doc, err := generateDoc(indexMatch, "", docModel{
+			NonComputedParameters: propertyDocumentation(provider.Schema, isNonComputed),
+			ComputedParameters:    propertyDocumentation(provider.Schema, isComputed),
+			Example:               example,
+		})
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
 	}
 
 	// populate resource documents
-	err = filepath.Walk(filepath.Join(folderpath, "resources"), func(path string, info os.FileInfo, err error) error {
-		if info.IsDir() {
-			return nil
-		}
-		fileName := info.Name()
-		fileNameWithoutExtension := fileName[0:strings.LastIndex(fileName, ".")]
-
-		for resourceName, resourceValue := range provider.ResourcesMap {
-			resourceNameWithoutProvider := resourceName[strings.Index(resourceName, "_")+1:]
-			if resourceNameWithoutProvider == fileNameWithoutExtension || resourceName == fileNameWithoutExtension {
-				populateResourceDoc(path, resourceValue)
-			}
-		}
-		return nil
-	})
+	resourceDocs, err := generateResourceDocs(filepath.Join(folderpath, "resources"), filepath.Join(examplesPath, "resources"), "resource", providerName, provider.ResourcesMap)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	docs = append(docs, resourceDocs...)
 
 	// populate data source documents
-	err = filepath.Walk(filepath.Join(folderpath, "data-sources"), func(path string, info os.FileInfo, err error) error {
-		if info.IsDir() {
-			return nil
-		}
-		fileName := info.Name()
-		fileNameWithoutExtension := fileName[0:strings.LastIndex(fileName, ".")]
+	dataSourceDocs, err := generateResourceDocs(filepath.Join(folderpath, "data-sources"), filepath.Join(examplesPath, "data-sources"), "data-source", providerName, provider.DataSourcesMap)
+	if err != nil {
+		return nil, err
+	}
+	docs = append(docs, dataSourceDocs...)
+
+	return docs, nil
+}
+
+// docModel represents the values that can be used in docgen markers
+type docModel struct {
+	NonComputedParameters string
+	ComputedParameters    string
+	Example               string
+	Examples              map[string]string
+}
 
-		for dataSourceName, dataSourceValue := range provider.DataSourcesMap {
-			dataSourceNameWithoutProvider := dataSourceName[strings.Index(dataSourceName, "_")+1:]
-			if dataSourceNameWithoutProvider == fileNameWithoutExtension || dataSourceName == fileNameWithoutExtension {
-				populateResourceDoc(path, dataSourceValue)
+func generateResourceDocs(folderpath string, examplesPath string, kind string, providerName string, resources map[string]*schema.Resource) ([]generatedDoc, error) {
+	resourceNames := make([]string, 0, len(resources))
+	for resourceName := range resources {
+		resourceNames = append(resourceNames, resourceName)
+	}
+	sort.Strings(resourceNames)
+
+	docs := []generatedDoc{}
+	for _, resourceName := range resourceNames {
+		resource := resources[resourceName]
+		resourceNameWithoutProvider := strings.TrimPrefix(resourceName, providerName+"_")
+
+		// documents may be named with or without the provider prefix
+		docPath := filepath.Join(folderpath, resourceNameWithoutProvider+".md")
+		for _, candidate := range []string{docPath, filepath.Join(folderpath, resourceName+".md")} {
+			if _, err := os.Stat(candidate); err == nil {
+				docPath = candidate
+				break
 			}
 		}
-		return nil
-	})
+
+		example, err := readExample(filepath.Join(examplesPath, resourceName, kind+".tf"))
+		if err != nil {
+			return nil, err
+		}
+		examples, err := readExamples(filepath.Join(examplesPath, resourceName))
+		if err != nil {
+			return nil, err
+		}
+		doc, err := generateDoc(docPath, defaultResourceTemplate(kind, resourceName, resourceNameWithoutProvider, resource), docModel{
+			NonComputedParameters: propertyDocumentation(resource.Schema, isNonComputed),
+			ComputedParameters:    propertyDocumentation(resource.Schema, isComputed),
+			Example:               example,
+			Examples:              examples,
+		})
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+// generateDoc renders the document at path, using defaultTemplate if the document does not exist
+func generateDoc(path string, defaultTemplate string, model docModel) (generatedDoc, error) {
+	doc := generatedDoc{Path: path}
+
+	template := defaultTemplate
+	existing, err := ioutil.ReadFile(path)
+	if err == nil {
+		doc.Exists = true
+		doc.Existing = string(existing)
+		template = doc.Existing
+	} else if !os.IsNotExist(err) {
+		return doc, err
+	}
+
+	doc.Content, err = renderTemplate(template, model)
+	return doc, err
+}
+
+// defaultResourceTemplate returns the template for resources and data sources without a document
+func defaultResourceTemplate(kind string, resourceName string, resourceNameWithoutProvider string, resource *schema.Resource) string {
+	title := strings.Title(strings.ReplaceAll(resourceNameWithoutProvider, "_", " "))
+	heading := title + " Resource"
+	if kind == "data-source" {
+		heading = title + " Data Source"
+	}
+
+	builder := &strings.Builder{}
+	writeLine(builder, "# ", heading)
+	writeLine(builder, "`", resourceName, "` ", resource.Description)
+	writeLine(builder, "")
+	writeLine(builder, "## Example Usage")
+	writeLine(builder, "<!-- docgen:Example -->")
+	writeLine(builder, "<!-- /docgen -->")
+	writeLine(builder, "")
+	writeLine(builder, "## Argument Reference")
+	writeLine(builder, "#### The following arguments are supported:")
+	writeLine(builder, "<!-- docgen:NonComputedParameters -->")
+	writeLine(builder, "<!-- /docgen -->")
+	writeLine(builder, "")
+	writeLine(builder, "## Attributes Reference")
+	writeLine(builder, "#### The following attributes are exported in addition to the arguments listed above:")
+	writeLine(builder, "* `id` - The ID of the ", strings.ToLower(title), ".")
+	writeLine(builder, "<!-- docgen:ComputedParameters -->")
+	writeLine(builder, "<!-- /docgen -->")
+	return builder.String()
+}
+
+// readExample returns the example configuration at path formatted as a code block, or nothing if there is no example
+func readExample(path string) (string, error) {
+	example, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
 	if err != nil {
-		return err
+		return "", err
 	}
+	return "```hcl\n" + strings.Trim(strings.ReplaceAll(string(example), "\r\n", "\n"), "\n") + "\n```", nil
+}
 
-	return nil
+// readExamples returns the example configurations in folderpath keyed by file name without the extension
+func readExamples(folderpath string) (map[string]string, error) {
+	paths, err := filepath.Glob(filepath.Join(folderpath, "*.tf"))
+	if err != nil {
+		return nil, err
+	}
+
+	examples := map[string]string{}
+	for _, path := range paths {
+		example, err := readExample(path)
+		if err != nil {
+			return nil, err
+		}
+		examples[strings.TrimSuffix(filepath.Base(path), ".tf")] = example
+	}
+	return examples, nil
 }
 
-func populateProviderDoc(filepath string, provider *schema.Provider) error {
-	return populateTemplate(filepath, struct {
-		NonComputedParameters string
-		ComputedParameters    string
-	}{
-		propertyDocumentation(provider.Schema, func(propKey string, propValue *schema.Schema) bool {
-			return !propValue.Computed
-		}),
-		propertyDocumentation(provider.Schema, func(propKey string, propValue *schema.Schema) bool {
-			return propValue.Computed
-		}),
-	})
+// isNonComputed returns true for properties that can be set in configuration, including optional computed properties
+func isNonComputed(propKey string, propValue *schema.Schema) bool {
+	return propValue.Required || propValue.Optional
 }
 
-func populateResourceDoc(filepath string, resource *schema.Resource) error {
-	return populateTemplate(filepath, struct {
-		NonComputedParameters string
-		ComputedParameters    string
-	}{
-		propertyDocumentation(resource.Schema, func(propKey string, propValue *schema.Schema) bool {
-			return !propValue.Computed
-		}),
-		propertyDocumentation(resource.Schema, func(propKey string, propValue *schema.Schema) bool {
-			return propValue.Computed
-		}),
-	})
+func isComputed(propKey string, propValue *schema.Schema) bool {
+	return !isNonComputed(propKey, propValue)
 }
 
 func propertyDocumentation(propertySchemas map[string]*schema.Schema, filter func(propKey string, propValue *schema.Schema) bool) string {
 	builder := &strings.Builder{}
-	writePropertyDocumentation(builder, propertySchemas, filter)
+	writePropertyDocumentation(builder, "", propertySchemas, filter)
 	return strings.Trim(builder.String(), " \r\n")
 }
-func writePropertyDocumentation(writer *strings.Builder, propertySchemas map[string]*schema.Schema, filter func(propKey string, propValue *schema.Schema) bool) {
+
+// writePropertyDocumentation writes a line for each property, followed by a section for each nested block.
+// Blocks within other blocks are named by their path, e.g. `table.column`, so every section has a unique anchor
+func writePropertyDocumentation(writer *strings.Builder, parentPath string, propertySchemas map[string]*schema.Schema, filter func(propKey string, propValue *schema.Schema) bool) {
 
 	filteredPropertySchemas := make(map[string]*schema.Schema)
 	for key, value := range propertySchemas {
@@ -127,12 +277,16 @@ func writePropertyDocumentation(writer *strings.Builder, propertySchemas map[str
 		descriptionSuffix := ""
 		res, isNestedResource := prop.Schema.Elem.(*schema.Resource)
 		if isNestedResource {
-			headerText := capitalizeFirstCharacter(indefiniteArticle(prop.Name)) + " `" + prop.Name + "` block supports the following:"
-			nestedObjects = append(nestedObjects, nestedObjectProperty{HeaderText: headerText, Name: prop.Name, NestedSchema: res.Schema})
-			descriptionSuffix = capitalizeFirstCharacter(indefiniteArticle(prop.Name)) + " [`" + prop.Name + "`](#" + headerTextToAnchorName(headerText) + ") block is defined below."
+			path := prop.Name
+			if parentPath != "" {
+				path = parentPath + "." + prop.Name
+			}
+			headerText := capitalizeFirstCharacter(indefiniteArticle(prop.Name)) + " `" + path + "` block supports the following:"
+			nestedObjects = append(nestedObjects, nestedObjectProperty{HeaderText: headerText, Path: path, NestedSchema: res.Schema})
+			descriptionSuffix = capitalizeFirstCharacter(indefiniteArticle(prop.Name)) + " [`" + path + "`](#" + headerTextToAnchorName(headerText) + ") block is defined below."
 		}
 
-		tagString := buildTagString(prop.Schema)
+		tagString := buildTagString(prop.Name, prop.Schema)
 		if tagString != "" {
 			tagString = tagString + " "
 		}
@@ -148,7 +302,7 @@ func writePropertyDocumentation(writer *strings.Builder, propertySchemas map[str
 
 		// Assuming heading levels is not ideal, but this is the only way to get anchors in terraform registry docs
 		writeLine(writer, "#### ", nestedProp.HeaderText)
-		writePropertyDocumentation(writer, nestedProp.NestedSchema, filter)
+		writePropertyDocumentation(writer, nestedProp.Path, nestedProp.NestedSchema, filter)
 	}
 }
 
@@ -176,7 +330,7 @@ func sortProperties(propertySchemas map[string]*schema.Schema) []property {
 	return propertyList
 }
 
-func buildTagString(attribute *schema.Schema) string {
+func buildTagString(propName string, attribute *schema.Schema) string {
 	var tags []string
 
 	if attribute.Optional {
@@ -192,6 +346,17 @@ func buildTagString(attribute *schema.Schema) string {
 		tags = append(tags, "Forces new resource")
 	}
 
+	var conflicts []string
+	for _, conflict := range append(append([]string{}, attribute.ConflictsWith...), attribute.ExactlyOneOf...) {
+		conflict = "`" + conflict + "`"
+		if conflict != "`"+propName+"`" && !containsString(conflicts, conflict) {
+			conflicts = append(conflicts, conflict)
+		}
+	}
+	if len(conflicts) > 0 {
+		tags = append(tags, "Conflicts with: "+strings.Join(conflicts, ", "))
+	}
+
 	if len(tags) == 0 {
 		return ""
 	}
@@ -0,0 +1,153 @@
+package docgen
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func testProvider() *schema.Provider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"tenant_id": {Type: schema.TypeString, Required: true, Description: "Tenant ID."},
+		},
+		ResourcesMap: map[string]*schema.Resource{
+			"test_table": {
+				Description: "represents a table.",
+				Schema: map[string]*schema.Schema{
+					"name": {Type: schema.TypeString, Required: true, ForceNew: true, Description: "Name of the table."},
+					"column": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						Description: "Columns of the table.",
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"name": {Type: schema.TypeString, Required: true, Description: "Name of the column."},
+								"format": {
+									Type:        schema.TypeList,
+									Optional:    true,
+									Description: "Format of the column.",
+									Elem: &schema.Resource{
+										Schema: map[string]*schema.Schema{
+											"pattern": {Type: schema.TypeString, Optional: true, Description: "Format pattern."},
+										},
+									},
+								},
+							},
+						},
+					},
+					"row_count": {Type: schema.TypeInt, Computed: true, Description: "Number of rows."},
+				},
+			},
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"test_table": {
+				Description: "returns a table.",
+				Schema: map[string]*schema.Schema{
+					"id":   {Type: schema.TypeString, Optional: true, Computed: true, ExactlyOneOf: []string{"id", "name"}, Description: "ID of the table."},
+					"name": {Type: schema.TypeString, Optional: true, Computed: true, ExactlyOneOf: []string{"id", "name"}, Description: "Name of the table."},
+				},
+			},
+		},
+	}
+}
+
+func writeTestFile(t *testing.T, path string, content string) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readTestFile(t *testing.T, path string) string {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+func TestPopulateTerraformDocs(t *testing.T) {
+	dir := t.TempDir()
+	docsPath := filepath.Join(dir, "docs")
+	writeTestFile(t, filepath.Join(docsPath, "index.md"), "# Provider\n<!-- docgen:NonComputedParameters -->\n<!-- /docgen -->\n")
+	writeTestFile(t, filepath.Join(docsPath, "resources", "table.md"), "# Table Resource\n## Example Usage\n<!-- docgen:Example -->\nstale\n<!-- /docgen -->\n<!-- docgen:Examples:columns -->\n<!-- /docgen -->\n<!-- docgen:NonComputedParameters -->\n<!-- /docgen -->\n<!-- docgen:ComputedParameters -->\n<!-- /docgen -->\n")
+	writeTestFile(t, filepath.Join(dir, "examples", "resources", "test_table", "resource.tf"), "resource \"test_table\" \"example\" {\r\n  name = \"sales\"\r\n}\r\n\r\n")
+	writeTestFile(t, filepath.Join(dir, "examples", "resources", "test_table", "columns.tf"), "resource \"test_table\" \"columns\" {\n  name = \"sales\"\n  column {\n    name = \"region\"\n  }\n}\n")
+
+	if err := PopulateTerraformDocs(docsPath, "test", testProvider()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedIndex := "# Provider\n<!-- docgen:NonComputedParameters -->\n* `tenant_id` - (Required) Tenant ID.\n<!-- /docgen -->\n"
+	if index := readTestFile(t, filepath.Join(docsPath, "index.md")); index != expectedIndex {
+		t.Errorf("expected index\n%s\ngot\n%s", expectedIndex, index)
+	}
+
+	expectedResource := "# Table Resource\n## Example Usage\n<!-- docgen:Example -->\n```hcl\nresource \"test_table\" \"example\" {\n  name = \"sales\"\n}\n```\n<!-- /docgen -->\n" +
+		"<!-- docgen:Examples:columns -->\n```hcl\nresource \"test_table\" \"columns\" {\n  name = \"sales\"\n  column {\n    name = \"region\"\n  }\n}\n```\n<!-- /docgen -->\n" +
+		"<!-- docgen:NonComputedParameters -->\n" +
+		"* `name` - (Required, Forces new resource) Name of the table.\n" +
+		"* `column` - (Optional) Columns of the table. A [`column`](#a-column-block-supports-the-following) block is defined below.\n" +
+		"\n---\n\n" +
+		"#### A `column` block supports the following:\n" +
+		"* `name` - (Required) Name of the column.\n" +
+		"* `format` - (Optional) Format of the column. A [`column.format`](#a-columnformat-block-supports-the-following) block is defined below.\n" +
+		"\n---\n\n" +
+		"#### A `column.format` block supports the following:\n" +
+		"* `pattern` - (Optional) Format pattern.\n" +
+		"<!-- /docgen -->\n" +
+		"<!-- docgen:ComputedParameters -->\n" +
+		"* `row_count` - Number of rows.\n" +
+		"<!-- /docgen -->\n"
+	if resource := readTestFile(t, filepath.Join(docsPath, "resources", "table.md")); resource != expectedResource {
+		t.Errorf("expected resource\n%s\ngot\n%s", expectedResource, resource)
+	}
+
+	// data sources without documentation are created from the default template
+	dataSource := readTestFile(t, filepath.Join(docsPath, "data-sources", "table.md"))
+	for _, expected := range []string{
+		"# Table Data Source\n`test_table` returns a table.\n",
+		"<!-- docgen:Example -->\n\n<!-- /docgen -->",
+		"* `id` - (Optional, Conflicts with: `name`) ID of the table.\n* `name` - (Optional, Conflicts with: `id`) Name of the table.\n",
+	} {
+		if !strings.Contains(dataSource, expected) {
+			t.Errorf("expected data source document to contain\n%s\ngot\n%s", expected, dataSource)
+		}
+	}
+}
+
+func TestCheckTerraformDocs(t *testing.T) {
+	dir := t.TempDir()
+	docsPath := filepath.Join(dir, "docs")
+	writeTestFile(t, filepath.Join(docsPath, "resources", "table.md"), "<!-- docgen:NonComputedParameters -->\n<!-- /docgen -->\n")
+
+	outdatedPaths, err := CheckTerraformDocs(docsPath, "test", testProvider())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedPaths := []string{filepath.Join(docsPath, "resources", "table.md"), filepath.Join(docsPath, "data-sources", "table.md")}
+	if !reflect.DeepEqual(outdatedPaths, expectedPaths) {
+		t.Errorf("expected outdated paths %v, got %v", expectedPaths, outdatedPaths)
+	}
+
+	// checking does not modify the documents
+	if resource := readTestFile(t, filepath.Join(docsPath, "resources", "table.md")); resource != "<!-- docgen:NonComputedParameters -->\n<!-- /docgen -->\n" {
+		t.Errorf("expected document to be unchanged, got\n%s", resource)
+	}
+
+	if err := PopulateTerraformDocs(docsPath, "test", testProvider()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	outdatedPaths, err = CheckTerraformDocs(docsPath, "test", testProvider())
+	if err != nil || len(outdatedPaths) != 0 {
+		t.Errorf("expected no outdated documents after populating, got %v with error %v", outdatedPaths, err)
+	}
+}
@@ -13,7 +13,7 @@ func writeLine(writer io.StringWriter, values ...string) {
 	for _, value := range values {
 		writer.WriteString(value)
 	}
-	writer.WriteString("\r\n")
+	writer.WriteString("\n")
 }
 
 func indefiniteArticle(noun string) string {
@@ -93,3 +93,12 @@ func writeToFile(filename string, data string) error {
 	}
 	return file.Sync()
 }
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,219 @@
+package pbixrewriter
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// dataMashup represents the binary DataMashup entry of a PBIX, as described in [MS-QDEFF].
+// The package parts are an OPC zip containing the Power Query formulas
+type dataMashup struct {
+	Version            uint32
+	PackageParts       []byte
+	Permissions        []byte
+	Metadata           []byte
+	PermissionBindings []byte
+}
+
+const dataMashupSectionPath = "Formulas/Section1.m"
+
+var mashupStringLiteralRegex = regexp.MustCompile(`#?"(?:[^"]|"")*"`)
+
+// SetMashupParametersPipelineFunc changes the default values of Power Query parameters within the DataMashup
+// of a PBIX. Values replacing text parameters are quoted, other values are written as M expressions.
+// This allows changing parameters that cannot be updated through the REST API, such as those not marked as required
+func SetMashupParametersPipelineFunc(parameters map[string]string) PipelineFunc {
+	return rewriteMashupSectionPipelineFunc(func(section string) (string, error) {
+		for _, name := range sortedKeys(parameters) {
+			parameterRegex := regexp.MustCompile(`(?s)(shared\s+` + mashupIdentifierPattern(name) + `\s*=\s*)("(?:[^"]|"")*"|[^";]*?)(\s+meta\s*\[[^\]]*IsParameterQuery\s*=\s*true)`)
+			match := parameterRegex.FindStringSubmatchIndex(section)
+			if match == nil {
+				return "", fmt.Errorf("parameter %s not found in DataMashup", name)
+			}
+
+			value := parameters[name]
+			if strings.HasPrefix(section[match[4]:match[5]], `"`) {
+				value = quoteMashupString(value)
+			}
+			section = section[:match[4]] + value + section[match[5]:]
+		}
+		return section, nil
+	})
+}
+
+// ReplaceMashupStringsPipelineFunc replaces text literals within the Power Query formulas of a PBIX,
+// such as server names and URLs passed to data source functions. Every literal exactly matching a key is replaced
+func ReplaceMashupStringsPipelineFunc(replacements map[string]string) PipelineFunc {
+	return rewriteMashupSectionPipelineFunc(func(section string) (string, error) {
+		found := map[string]bool{}
+		section = mashupStringLiteralRegex.ReplaceAllStringFunc(section, func(literal string) string {
+			// #"..." is a quoted identifier rather than text
+			if strings.HasPrefix(literal, "#") {
+				return literal
+			}
+			value := unquoteMashupString(literal)
+			replacement, ok := replacements[value]
+			if !ok {
+				return literal
+			}
+			found[value] = true
+			return quoteMashupString(replacement)
+		})
+
+		for _, original := range sortedKeys(replacements) {
+			if !found[original] {
+				return "", fmt.Errorf("text %q not found in DataMashup", original)
+			}
+		}
+		return section, nil
+	})
+}
+
+func rewriteMashupSectionPipelineFunc(rewriteSection func(section string) (string, error)) PipelineFunc {
+	return func(file *zip.File, reader io.Reader, next PipelineFuncNext) error {
+		if file.Name != "DataMashup" {
+			return next(file, reader)
+		}
+
+		data, err := ioutil.ReadAll(reader)
+		if err != nil {
+			return err
+		}
+
+		mashup, err := readDataMashup(data)
+		if err != nil {
+			return err
+		}
+
+		mashup.PackageParts, err = rewriteZipEntry(mashup.PackageParts, dataMashupSectionPath, func(content []byte) ([]byte, error) {
+			section, err := rewriteSection(string(content))
+			return []byte(section), err
+		})
+		if err != nil {
+			return err
+		}
+
+		return next(file, bytes.NewReader(writeDataMashup(mashup)))
+	}
+}
+
+func readDataMashup(data []byte) (*dataMashup, error) {
+	reader := bytes.NewReader(data)
+
+	mashup := dataMashup{}
+	if err := binary.Read(reader, binary.LittleEndian, &mashup.Version); err != nil {
+		return nil, fmt.Errorf("invalid DataMashup: %w", err)
+	}
+
+	sections := []*[]byte{&mashup.PackageParts, &mashup.Permissions, &mashup.Metadata, &mashup.PermissionBindings}
+	for _, section := range sections {
+		var length uint32
+		if err := binary.Read(reader, binary.LittleEndian, &length); err != nil {
+			return nil, fmt.Errorf("invalid DataMashup: %w", err)
+		}
+		if int64(length) > int64(reader.Len()) {
+			return nil, fmt.Errorf("invalid DataMashup: section length %d exceeds remaining %d bytes", length, reader.Len())
+		}
+		*section = make([]byte, length)
+		if _, err := io.ReadFull(reader, *section); err != nil {
+			return nil, fmt.Errorf("invalid DataMashup: %w", err)
+		}
+	}
+
+	return &mashup, nil
+}
+
+// writeDataMashup serializes the DataMashup. Permission bindings are kept as is, Power BI Desktop
+// will ask to confirm data source permissions again as they no longer match the package parts
+func writeDataMashup(mashup *dataMashup) []byte {
+	var buffer bytes.Buffer
+	binary.Write(&buffer, binary.LittleEndian, mashup.Version)
+	for _, section := range [][]byte{mashup.PackageParts, mashup.Permissions, mashup.Metadata, mashup.PermissionBindings} {
+		binary.Write(&buffer, binary.LittleEndian, uint32(len(section)))
+		buffer.Write(section)
+	}
+	return buffer.Bytes()
+}
+
+// rewriteZipEntry rewrites a single entry of an in memory zip, keeping all other entries unchanged
+func rewriteZipEntry(data []byte, name string, rewrite func(content []byte) ([]byte, error)) ([]byte, error) {
+	zipReader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	zipWriter := zip.NewWriter(&buffer)
+	found := false
+	for _, file := range zipReader.File {
+		content, err := readZipFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		if strings.TrimPrefix(file.Name, "/") == name {
+			found = true
+			content, err = rewrite(content)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// sizes are recalculated when writing, extra fields are dropped as they may hold stale zip64 sizes
+		header := file.FileHeader
+		header.Extra = nil
+		writer, err := zipWriter.CreateHeader(&header)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := writer.Write(content); err != nil {
+			return nil, err
+		}
+	}
+	if err := zipWriter.Close(); err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%s not found in DataMashup", name)
+	}
+	return buffer.Bytes(), nil
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return ioutil.ReadAll(reader)
+}
+
+// mashupIdentifierPattern matches an M identifier written either plainly or as a quoted identifier
+func mashupIdentifierPattern(name string) string {
+	return `(?:` + regexp.QuoteMeta(name) + `|#` + regexp.QuoteMeta(quoteMashupString(name)) + `)`
+}
+
+func quoteMashupString(value string) string {
+	return `"` + strings.ReplaceAll(value, `"`, `""`) + `"`
+}
+
+func unquoteMashupString(literal string) string {
+	return strings.ReplaceAll(literal[1:len(literal)-1], `""`, `"`)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,132 @@
+package pbixrewriter
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+const samplePbix = "../powerbi/resource_pbix_test_sample1.pbix"
+
+func TestSetMashupParametersPipelineFunc(t *testing.T) {
+	section := runMashupPipelineFunc(t, SetMashupParametersPipelineFunc(map[string]string{
+		"ParamOne": `New "quoted" value`,
+	}))
+
+	if !strings.Contains(section, `shared ParamOne = "New ""quoted"" value" meta [IsParameterQuery=true`) {
+		t.Errorf("expected ParamOne to be replaced, got\n%s", section)
+	}
+	if !strings.Contains(section, `shared ParamTwo = "ParamTwoValue" meta [IsParameterQuery=true`) {
+		t.Errorf("expected ParamTwo to be unchanged, got\n%s", section)
+	}
+}
+
+func TestReplaceMashupStringsPipelineFunc(t *testing.T) {
+	section := runMashupPipelineFunc(t, ReplaceMashupStringsPipelineFunc(map[string]string{
+		"https://services.odata.org/V3/OData/OData.svc/": "https://services.odata.org/V4/OData/OData.svc/",
+	}))
+
+	if strings.Contains(section, "V3/OData") {
+		t.Errorf("expected all OData URLs to be replaced, got\n%s", section)
+	}
+	if strings.Count(section, `OData.Feed("https://services.odata.org/V4/OData/OData.svc/"`) != 2 {
+		t.Errorf("expected both OData URLs to be replaced, got\n%s", section)
+	}
+	if !strings.Contains(section, `#"Changed Type"`) {
+		t.Errorf("expected quoted identifiers to be unchanged, got\n%s", section)
+	}
+}
+
+func TestMashupPipelineFuncs_notFound(t *testing.T) {
+	pipelineFuncs := map[string]PipelineFunc{
+		"parameter": SetMashupParametersPipelineFunc(map[string]string{"ParamThree": "value"}),
+		"text":      ReplaceMashupStringsPipelineFunc(map[string]string{"https://example.com": "value"}),
+	}
+
+	for name, pipelineFunc := range pipelineFuncs {
+		t.Run(name, func(t *testing.T) {
+			file, reader := openSampleDataMashup(t)
+			defer reader.Close()
+
+			err := pipelineFunc(file, reader, func(file *zip.File, reader io.Reader) error { return nil })
+			if err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+}
+
+func TestWriteDataMashup_roundTrip(t *testing.T) {
+	file, reader := openSampleDataMashup(t)
+	defer reader.Close()
+
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mashup, err := readDataMashup(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(writeDataMashup(mashup), data) {
+		t.Errorf("expected %s to be unchanged after reading and writing", file.Name)
+	}
+
+	if _, err := readDataMashup(data[:100]); err == nil {
+		t.Errorf("expected error for truncated DataMashup")
+	}
+}
+
+func runMashupPipelineFunc(t *testing.T, pipelineFunc PipelineFunc) string {
+	file, reader := openSampleDataMashup(t)
+	defer reader.Close()
+
+	var output []byte
+	err := pipelineFunc(file, reader, func(file *zip.File, reader io.Reader) error {
+		var err error
+		output, err = ioutil.ReadAll(reader)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mashup, err := readDataMashup(output)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var section []byte
+	_, err = rewriteZipEntry(mashup.PackageParts, dataMashupSectionPath, func(content []byte) ([]byte, error) {
+		section = content
+		return content, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return string(section)
+}
+
+func openSampleDataMashup(t *testing.T) (*zip.File, io.ReadCloser) {
+	zipReader, err := zip.OpenReader(samplePbix)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() { zipReader.Close() })
+
+	for _, file := range zipReader.File {
+		if file.Name == "DataMashup" {
+			reader, err := file.Open()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			return file, reader
+		}
+	}
+	t.Fatalf("DataMashup not found in %s", samplePbix)
+	return nil, nil
+}
@@ -0,0 +1,147 @@
+package pbixrewriter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Kinds of change reported by DiffPbix
+const (
+	ChangeAdded    = "added"
+	ChangeRemoved  = "removed"
+	ChangeModified = "modified"
+)
+
+// PbixChange represents a single difference between the contents of two PBIX files
+type PbixChange struct {
+	Change    string `json:"change"`
+	Component string `json:"component"`
+	Name      string `json:"name"`
+	Before    string `json:"before,omitempty"`
+	After     string `json:"after,omitempty"`
+}
+
+// PbixComponent represents a single part of the content of a PBIX that is compared by DiffPbix
+type PbixComponent struct {
+	Component   string
+	Name        string
+	Description string
+}
+
+// Key returns a key unique to the component within a PBIX
+func (component PbixComponent) Key() string {
+	return component.Component + " " + component.Name
+}
+
+// DiffPbixFiles compares the contents of two PBIX files
+func DiffPbixFiles(beforePbixFile string, afterPbixFile string) ([]PbixChange, error) {
+	before, err := InspectPbixFile(beforePbixFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", beforePbixFile, err)
+	}
+	after, err := InspectPbixFile(afterPbixFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", afterPbixFile, err)
+	}
+	return DiffPbix(before, after), nil
+}
+
+// DiffPbix compares the pages, visuals, measures, queries and connections of two PBIX files.
+// Changes are ordered by component and name
+func DiffPbix(before *PbixMetadata, after *PbixMetadata) []PbixChange {
+	beforeComponents := map[string]PbixComponent{}
+	for _, component := range PbixComponents(before) {
+		beforeComponents[component.Key()] = component
+	}
+	afterComponents := map[string]PbixComponent{}
+	for _, component := range PbixComponents(after) {
+		afterComponents[component.Key()] = component
+	}
+
+	changes := []PbixChange{}
+	for key, beforeComponent := range beforeComponents {
+		afterComponent, ok := afterComponents[key]
+		if !ok {
+			changes = append(changes, PbixChange{Change: ChangeRemoved, Component: beforeComponent.Component, Name: beforeComponent.Name, Before: beforeComponent.Description})
+		} else if afterComponent.Description != beforeComponent.Description {
+			changes = append(changes, PbixChange{Change: ChangeModified, Component: beforeComponent.Component, Name: beforeComponent.Name, Before: beforeComponent.Description, After: afterComponent.Description})
+		}
+	}
+	for key, afterComponent := range afterComponents {
+		if _, ok := beforeComponents[key]; !ok {
+			changes = append(changes, PbixChange{Change: ChangeAdded, Component: afterComponent.Component, Name: afterComponent.Name, After: afterComponent.Description})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Component != changes[j].Component {
+			return changes[i].Component < changes[j].Component
+		}
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
+
+// PbixComponents lists the parts of a PBIX that are compared by DiffPbix, each with a readable description
+func PbixComponents(metadata *PbixMetadata) []PbixComponent {
+	components := []PbixComponent{}
+
+	for _, page := range metadata.Pages {
+		description := fmt.Sprintf("%s, position %d", page.Name, page.Ordinal)
+		if page.Hidden {
+			description += ", hidden"
+		}
+		components = append(components, PbixComponent{Component: "page", Name: page.DisplayName, Description: description})
+
+		for _, visual := range page.Visuals {
+			description := fmt.Sprintf("%s at (%.0f, %.0f) size %.0fx%.0f", visual.Type, visual.X, visual.Y, visual.Width, visual.Height)
+			if len(visual.Fields) > 0 {
+				description += ", fields " + strings.Join(visual.Fields, ", ")
+			}
+			components = append(components, PbixComponent{Component: "visual", Name: page.DisplayName + "/" + visual.Name, Description: description})
+		}
+	}
+
+	for _, measure := range metadata.Measures {
+		components = append(components, PbixComponent{Component: "measure", Name: measure.Table + "[" + measure.Name + "]", Description: measure.Expression})
+	}
+	for _, query := range metadata.Queries {
+		components = append(components, PbixComponent{Component: "query", Name: query.Name, Description: query.Expression})
+	}
+	for _, connection := range metadata.Connections {
+		components = append(components, PbixComponent{Component: "connection", Name: connection.Name, Description: connection.ConnectionType + ": " + connection.ConnectionString})
+	}
+
+	return components
+}
+
+// FormatPbixChanges formats changes as readable text, with one block per change
+func FormatPbixChanges(changes []PbixChange) string {
+	if len(changes) == 0 {
+		return "No changes\n"
+	}
+
+	var builder strings.Builder
+	for _, change := range changes {
+		switch change.Change {
+		case ChangeAdded:
+			fmt.Fprintf(&builder, "+ %s %q\n", change.Component, change.Name)
+			writeIndentedLines(&builder, "    + ", change.After)
+		case ChangeRemoved:
+			fmt.Fprintf(&builder, "- %s %q\n", change.Component, change.Name)
+			writeIndentedLines(&builder, "    - ", change.Before)
+		default:
+			fmt.Fprintf(&builder, "~ %s %q\n", change.Component, change.Name)
+			writeIndentedLines(&builder, "    - ", change.Before)
+			writeIndentedLines(&builder, "    + ", change.After)
+		}
+	}
+	return builder.String()
+}
+
+func writeIndentedLines(builder *strings.Builder, prefix string, text string) {
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		builder.WriteString(prefix + strings.TrimRight(line, "\r") + "\n")
+	}
+}
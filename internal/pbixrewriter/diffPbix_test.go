@@ -0,0 +1,72 @@
+package pbixrewriter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffPbixFiles(t *testing.T) {
+	changes, err := DiffPbixFiles(samplePbix, "../powerbi/resource_pbix_test_sample2.pbix")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	summary := []string{}
+	for _, change := range changes {
+		summary = append(summary, change.Change+" "+change.Component+" "+change.Name)
+	}
+	expected := []string{
+		"modified query Names",
+		"removed query ProductDetails",
+		"removed query Products",
+		"modified visual Page 1/09072f927c1a4121c6b9",
+		"removed visual Page 1/709d1a0db20d7b3b685d",
+	}
+	if !reflect.DeepEqual(summary, expected) {
+		t.Errorf("expected changes %v, got %v", expected, summary)
+	}
+
+	if changes[3].Before != "tableEx at (10, 0) size 280x280, fields Names.Name" {
+		t.Errorf("unexpected visual description %s", changes[3].Before)
+	}
+}
+
+func TestDiffPbixFiles_unchanged(t *testing.T) {
+	changes, err := DiffPbixFiles(samplePbix, samplePbix)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("expected no changes, got %v", changes)
+	}
+}
+
+func TestFormatPbixChanges(t *testing.T) {
+	text := FormatPbixChanges([]PbixChange{
+		{Change: ChangeAdded, Component: "page", Name: "Details", After: "ReportSection2, position 1"},
+		{Change: ChangeModified, Component: "query", Name: "Sales", Before: "let\n    Source = 1\nin\n    Source", After: "let\n    Source = 2\nin\n    Source"},
+		{Change: ChangeRemoved, Component: "measure", Name: "Sales[Total]", Before: "SUM(Sales[Amount])"},
+	})
+
+	expected := `+ page "Details"
+    + ReportSection2, position 1
+~ query "Sales"
+    - let
+    -     Source = 1
+    - in
+    -     Source
+    + let
+    +     Source = 2
+    + in
+    +     Source
+- measure "Sales[Total]"
+    - SUM(Sales[Amount])
+`
+	if text != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, text)
+	}
+
+	if FormatPbixChanges(nil) != "No changes\n" {
+		t.Errorf("expected no changes message")
+	}
+}
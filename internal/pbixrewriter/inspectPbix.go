@@ -0,0 +1,538 @@
+package pbixrewriter
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf16"
+)
+
+// Connection types reported by InspectPbix
+const (
+	ConnectionTypeNone        = "None"
+	ConnectionTypeImport      = "Import"
+	ConnectionTypeDirectQuery = "DirectQuery"
+	ConnectionTypeComposite   = "Composite"
+	ConnectionTypeLive        = "Live"
+	ConnectionTypeUnknown     = "Unknown"
+)
+
+// PbixMetadata represents the contents of a PBIX file as read by InspectPbix
+type PbixMetadata struct {
+	Version        string
+	ConnectionType string
+	DatasetID      string
+	Pages          []PbixPage
+	Bookmarks      []PbixBookmark
+	Parameters     []PbixParameter
+	Queries        []PbixQuery
+	Measures       []PbixMeasure
+	Connections    []PbixConnection
+}
+
+// PbixPage represents a single page of the report within a PBIX
+type PbixPage struct {
+	Name        string
+	DisplayName string
+	Ordinal     int
+	Hidden      bool
+	Visuals     []PbixVisual
+}
+
+// PbixVisual represents a single visual on a page
+type PbixVisual struct {
+	Name   string
+	Type   string
+	X      float64
+	Y      float64
+	Width  float64
+	Height float64
+	Fields []string
+}
+
+// PbixBookmark represents a single bookmark, bookmarks within groups are flattened
+type PbixBookmark struct {
+	Name        string
+	DisplayName string
+	Group       string
+}
+
+// PbixParameter represents a single Power Query parameter
+type PbixParameter struct {
+	Name  string
+	Value string
+	Type  string
+}
+
+// PbixQuery represents a single Power Query query, including parameters
+type PbixQuery struct {
+	Name       string
+	Expression string
+}
+
+// PbixMeasure represents a single DAX measure. Measures can only be read from a DataModelSchema
+type PbixMeasure struct {
+	Table      string
+	Name       string
+	Expression string
+}
+
+// PbixConnection represents a single connection of a report to a dataset or Analysis Services model
+type PbixConnection struct {
+	Name             string
+	ConnectionType   string
+	ConnectionString string
+}
+
+type pbixConnections struct {
+	Connections []struct {
+		Name                 string
+		ConnectionType       string
+		ConnectionString     string
+		PbiModelDatabaseName string
+	}
+	RemoteArtifacts []struct {
+		DatasetID string `json:"DatasetId"`
+	}
+}
+
+type pbixDataModelSchema struct {
+	Model struct {
+		Tables []struct {
+			Name       string
+			Partitions []struct {
+				Mode string
+			}
+			Measures []struct {
+				Name       string
+				Expression json.RawMessage
+			}
+		}
+		Expressions []struct {
+			Name       string
+			Kind       string
+			Expression json.RawMessage
+		}
+	}
+}
+
+type pbixLayout struct {
+	Config   string
+	Sections []struct {
+		Name             string
+		DisplayName      string
+		Ordinal          int
+		Config           string
+		VisualContainers []struct {
+			X      float64
+			Y      float64
+			Width  float64
+			Height float64
+			Config string
+		}
+	}
+}
+
+type pbixLayoutConfig struct {
+	Bookmarks []pbixLayoutBookmark
+}
+
+type pbixLayoutBookmark struct {
+	Name        string
+	DisplayName string
+	Children    []pbixLayoutBookmark
+}
+
+type pbixSectionConfig struct {
+	Visibility int
+}
+
+type pbixVisualConfig struct {
+	Name         string
+	SingleVisual *struct {
+		VisualType  string
+		Projections map[string][]struct {
+			QueryRef string
+		}
+	}
+	SingleVisualGroup *struct {
+		DisplayName string
+	}
+}
+
+var mashupParameterRegex = regexp.MustCompile(`(?s)shared\s+(#"(?:[^"]|"")*"|[\w.]+)\s*=\s*("(?:[^"]|"")*"|[^";]*?)\s+meta\s*\[([^\]]*IsParameterQuery\s*=\s*true[^\]]*)\]`)
+var mashupParameterTypeRegex = regexp.MustCompile(`Type\s*=\s*"([^"]*)"`)
+var mashupSharedRegex = regexp.MustCompile(`(?m)^\s*shared\s+(#"(?:[^"]|"")*"|[\w.]+)\s*=`)
+var mashupPlainIdentifierRegex = regexp.MustCompile(`^[A-Za-z_][\w.]*$`)
+
+// InspectPbixFile reads the metadata of a PBIX or PBIT file without modifying it
+func InspectPbixFile(pbixFile string) (*PbixMetadata, error) {
+	zipReader, err := zip.OpenReader(pbixFile)
+	if err != nil {
+		return nil, err
+	}
+	defer zipReader.Close()
+
+	return InspectPbix(&zipReader.Reader)
+}
+
+// InspectPbix reads the Version, Connections, DataModelSchema, DataMashup and Report/Layout entries
+// of a PBIX. Files containing a DataModel rather than a DataModelSchema are reported as Unknown, as the
+// storage mode of their tables cannot be read
+func InspectPbix(input *zip.Reader) (*PbixMetadata, error) {
+	entries := map[string]*zip.File{}
+	for _, file := range input.File {
+		entries[file.Name] = file
+	}
+
+	metadata := PbixMetadata{
+		ConnectionType: ConnectionTypeNone,
+		Pages:          []PbixPage{},
+		Bookmarks:      []PbixBookmark{},
+		Parameters:     []PbixParameter{},
+		Queries:        []PbixQuery{},
+		Measures:       []PbixMeasure{},
+		Connections:    []PbixConnection{},
+	}
+
+	if file, ok := entries["Version"]; ok {
+		content, err := readPbixText(file)
+		if err != nil {
+			return nil, err
+		}
+		metadata.Version = strings.TrimSpace(content)
+	}
+
+	if file, ok := entries["Connections"]; ok {
+		if err := inspectConnections(file, &metadata); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, ok := entries["DataModel"]; ok && metadata.ConnectionType == ConnectionTypeNone {
+		metadata.ConnectionType = ConnectionTypeUnknown
+	}
+
+	if file, ok := entries["DataModelSchema"]; ok {
+		if err := inspectDataModelSchema(file, &metadata); err != nil {
+			return nil, err
+		}
+	}
+
+	// the DataMashup holds the parameters of a PBIX, templates only hold them in the DataModelSchema
+	if file, ok := entries["DataMashup"]; ok {
+		if err := inspectDataMashup(file, &metadata); err != nil {
+			return nil, err
+		}
+	}
+
+	if file, ok := entries["Report/Layout"]; ok {
+		if err := inspectLayout(file, &metadata); err != nil {
+			return nil, err
+		}
+	}
+
+	return &metadata, nil
+}
+
+func inspectConnections(file *zip.File, metadata *PbixMetadata) error {
+	content, err := readPbixText(file)
+	if err != nil {
+		return err
+	}
+
+	var connections pbixConnections
+	if err := json.Unmarshal([]byte(content), &connections); err != nil {
+		return fmt.Errorf("invalid %s: %w", file.Name, err)
+	}
+
+	for _, connection := range connections.Connections {
+		metadata.Connections = append(metadata.Connections, PbixConnection{
+			Name:             connection.Name,
+			ConnectionType:   connection.ConnectionType,
+			ConnectionString: connection.ConnectionString,
+		})
+		if strings.HasSuffix(strings.ToLower(connection.ConnectionType), "live") {
+			metadata.ConnectionType = ConnectionTypeLive
+			if connection.ConnectionType == "pbiServiceLive" {
+				metadata.DatasetID = connection.PbiModelDatabaseName
+			}
+		}
+	}
+	if metadata.DatasetID == "" && len(connections.RemoteArtifacts) > 0 {
+		metadata.DatasetID = connections.RemoteArtifacts[0].DatasetID
+	}
+	return nil
+}
+
+func inspectDataModelSchema(file *zip.File, metadata *PbixMetadata) error {
+	content, err := readPbixText(file)
+	if err != nil {
+		return err
+	}
+
+	var dataModelSchema pbixDataModelSchema
+	if err := json.Unmarshal([]byte(content), &dataModelSchema); err != nil {
+		return fmt.Errorf("invalid %s: %w", file.Name, err)
+	}
+
+	modes := map[string]bool{}
+	for _, table := range dataModelSchema.Model.Tables {
+		for _, measure := range table.Measures {
+			expression, err := unmarshalModelExpression(measure.Expression)
+			if err != nil {
+				return fmt.Errorf("invalid measure %s in %s: %w", measure.Name, file.Name, err)
+			}
+			metadata.Measures = append(metadata.Measures, PbixMeasure{Table: table.Name, Name: measure.Name, Expression: expression})
+		}
+		for _, partition := range table.Partitions {
+			mode := strings.ToLower(partition.Mode)
+			if mode == "" || mode == "default" {
+				mode = "import"
+			}
+			modes[mode] = true
+		}
+	}
+	switch {
+	case modes["directquery"] && (modes["import"] || modes["dual"]):
+		metadata.ConnectionType = ConnectionTypeComposite
+	case modes["directquery"]:
+		metadata.ConnectionType = ConnectionTypeDirectQuery
+	case len(modes) > 0:
+		metadata.ConnectionType = ConnectionTypeImport
+	}
+
+	for _, expression := range dataModelSchema.Model.Expressions {
+		text, err := unmarshalModelExpression(expression.Expression)
+		if err != nil {
+			return fmt.Errorf("invalid expression %s in %s: %w", expression.Name, file.Name, err)
+		}
+		metadata.Parameters = append(metadata.Parameters, parseMashupParameters("shared "+quoteMashupIdentifier(expression.Name)+" = "+text+";")...)
+	}
+	return nil
+}
+
+func inspectDataMashup(file *zip.File, metadata *PbixMetadata) error {
+	data, err := readZipFile(file)
+	if err != nil {
+		return err
+	}
+
+	mashup, err := readDataMashup(data)
+	if err != nil {
+		return err
+	}
+
+	var section []byte
+	_, err = rewriteZipEntry(mashup.PackageParts, dataMashupSectionPath, func(content []byte) ([]byte, error) {
+		section = content
+		return content, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	metadata.Parameters = parseMashupParameters(string(section))
+	metadata.Queries = parseMashupQueries(string(section))
+	return nil
+}
+
+// unmarshalModelExpression reads an expression of a DataModelSchema, which is either a single string or an array of lines
+func unmarshalModelExpression(expression json.RawMessage) (string, error) {
+	if len(expression) == 0 {
+		return "", nil
+	}
+
+	var text string
+	if err := json.Unmarshal(expression, &text); err == nil {
+		return text, nil
+	}
+	var lines []string
+	if err := json.Unmarshal(expression, &lines); err != nil {
+		return "", err
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func inspectLayout(file *zip.File, metadata *PbixMetadata) error {
+	content, err := readPbixText(file)
+	if err != nil {
+		return err
+	}
+
+	var layout pbixLayout
+	if err := json.Unmarshal([]byte(content), &layout); err != nil {
+		return fmt.Errorf("invalid %s: %w", file.Name, err)
+	}
+
+	for _, section := range layout.Sections {
+		var sectionConfig pbixSectionConfig
+		if err := unmarshalLayoutConfig(section.Config, &sectionConfig); err != nil {
+			return fmt.Errorf("invalid config of page %s in %s: %w", section.Name, file.Name, err)
+		}
+
+		page := PbixPage{
+			Name:        section.Name,
+			DisplayName: section.DisplayName,
+			Ordinal:     section.Ordinal,
+			Hidden:      sectionConfig.Visibility == 1,
+			Visuals:     []PbixVisual{},
+		}
+		for _, visualContainer := range section.VisualContainers {
+			var visualConfig pbixVisualConfig
+			if err := unmarshalLayoutConfig(visualContainer.Config, &visualConfig); err != nil {
+				return fmt.Errorf("invalid config of visual on page %s in %s: %w", section.Name, file.Name, err)
+			}
+
+			visual := PbixVisual{
+				Name:   visualConfig.Name,
+				X:      visualContainer.X,
+				Y:      visualContainer.Y,
+				Width:  visualContainer.Width,
+				Height: visualContainer.Height,
+				Fields: []string{},
+			}
+			if visualConfig.SingleVisual != nil {
+				visual.Type = visualConfig.SingleVisual.VisualType
+				for _, role := range sortedProjectionRoles(visualConfig.SingleVisual.Projections) {
+					for _, projection := range visualConfig.SingleVisual.Projections[role] {
+						visual.Fields = append(visual.Fields, projection.QueryRef)
+					}
+				}
+			} else if visualConfig.SingleVisualGroup != nil {
+				visual.Type = "group"
+			}
+			page.Visuals = append(page.Visuals, visual)
+		}
+		metadata.Pages = append(metadata.Pages, page)
+	}
+	sort.SliceStable(metadata.Pages, func(i, j int) bool {
+		return metadata.Pages[i].Ordinal < metadata.Pages[j].Ordinal
+	})
+
+	var layoutConfig pbixLayoutConfig
+	if err := unmarshalLayoutConfig(layout.Config, &layoutConfig); err != nil {
+		return fmt.Errorf("invalid config in %s: %w", file.Name, err)
+	}
+	for _, bookmark := range layoutConfig.Bookmarks {
+		if len(bookmark.Children) == 0 {
+			metadata.Bookmarks = append(metadata.Bookmarks, PbixBookmark{Name: bookmark.Name, DisplayName: bookmark.DisplayName})
+		}
+		for _, child := range bookmark.Children {
+			metadata.Bookmarks = append(metadata.Bookmarks, PbixBookmark{Name: child.Name, DisplayName: child.DisplayName, Group: bookmark.DisplayName})
+		}
+	}
+	return nil
+}
+
+// unmarshalLayoutConfig parses the JSON encoded config strings nested within the layout
+func unmarshalLayoutConfig(config string, v interface{}) error {
+	if config == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(config), v)
+}
+
+func parseMashupParameters(section string) []PbixParameter {
+	parameters := []PbixParameter{}
+	for _, match := range mashupParameterRegex.FindAllStringSubmatch(section, -1) {
+		parameter := PbixParameter{
+			Name:  match[1],
+			Value: strings.TrimSpace(match[2]),
+		}
+		if strings.HasPrefix(parameter.Name, "#") {
+			parameter.Name = unquoteMashupString(parameter.Name[1:])
+		}
+		if strings.HasPrefix(parameter.Value, `"`) {
+			parameter.Value = unquoteMashupString(parameter.Value)
+		}
+		if typeMatch := mashupParameterTypeRegex.FindStringSubmatch(match[3]); typeMatch != nil {
+			parameter.Type = typeMatch[1]
+		}
+		parameters = append(parameters, parameter)
+	}
+	return parameters
+}
+
+// parseMashupQueries splits a Power Query section into its shared queries
+func parseMashupQueries(section string) []PbixQuery {
+	queries := []PbixQuery{}
+	matches := mashupSharedRegex.FindAllStringSubmatchIndex(section, -1)
+	for i, match := range matches {
+		end := len(section)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+
+		name := section[match[2]:match[3]]
+		if strings.HasPrefix(name, "#") {
+			name = unquoteMashupString(name[1:])
+		}
+		expression := strings.TrimSpace(section[match[1]:end])
+		expression = strings.TrimSpace(strings.TrimSuffix(expression, ";"))
+		queries = append(queries, PbixQuery{Name: name, Expression: expression})
+	}
+	return queries
+}
+
+func sortedProjectionRoles(projections map[string][]struct{ QueryRef string }) []string {
+	roles := make([]string, 0, len(projections))
+	for role := range projections {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+func quoteMashupIdentifier(name string) string {
+	if mashupPlainIdentifierRegex.MatchString(name) {
+		return name
+	}
+	return "#" + quoteMashupString(name)
+}
+
+// readPbixText reads a text entry of a PBIX, which are a mix of UTF-8 and UTF-16LE
+func readPbixText(file *zip.File) (string, error) {
+	data, err := readZipFile(file)
+	if err != nil {
+		return "", err
+	}
+
+	text, _ := decodePbixText(data)
+	return text, nil
+}
+
+// decodePbixText decodes a text entry of a PBIX, returning whether it was encoded as UTF-16LE
+func decodePbixText(data []byte) (string, bool) {
+	data = bytes.TrimPrefix(data, []byte{0xEF, 0xBB, 0xBF})
+	isUTF16 := bytes.HasPrefix(data, []byte{0xFF, 0xFE}) || (len(data) >= 2 && data[0] != 0 && data[1] == 0)
+	if !isUTF16 {
+		return string(data), false
+	}
+
+	data = bytes.TrimPrefix(data, []byte{0xFF, 0xFE})
+	codeUnits := make([]uint16, len(data)/2)
+	for i := range codeUnits {
+		codeUnits[i] = binary.LittleEndian.Uint16(data[i*2:])
+	}
+	return string(utf16.Decode(codeUnits)), true
+}
+
+// encodePbixText encodes a text entry of a PBIX, without a byte order mark as written by Power BI Desktop
+func encodePbixText(text string, isUTF16 bool) []byte {
+	if !isUTF16 {
+		return []byte(text)
+	}
+
+	codeUnits := utf16.Encode([]rune(text))
+	data := make([]byte, len(codeUnits)*2)
+	for i, codeUnit := range codeUnits {
+		binary.LittleEndian.PutUint16(data[i*2:], codeUnit)
+	}
+	return data
+}
@@ -0,0 +1,149 @@
+package pbixrewriter
+
+import (
+	"encoding/binary"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"unicode/utf16"
+)
+
+func TestInspectPbixFile(t *testing.T) {
+	metadata, err := InspectPbixFile(samplePbix)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := &PbixMetadata{
+		Version:        "1.17",
+		ConnectionType: ConnectionTypeUnknown,
+		Pages: []PbixPage{
+			{Name: "ReportSection", DisplayName: "Page 1", Ordinal: 0, Visuals: []PbixVisual{
+				{Name: "09072f927c1a4121c6b9", Type: "tableEx", X: 10, Width: 280, Height: 280, Fields: []string{"Names.Name"}},
+				{Name: "709d1a0db20d7b3b685d", Type: "tableEx", Y: 175.0759878419453, Width: 280.1215805471125, Height: 280.1215805471125, Fields: []string{"Products.Name"}},
+			}},
+		},
+		Bookmarks: []PbixBookmark{},
+		Parameters: []PbixParameter{
+			{Name: "ParamOne", Value: "ParamOneValue", Type: "Text"},
+			{Name: "ParamTwo", Value: "ParamTwoValue", Type: "Text"},
+		},
+		Measures:    []PbixMeasure{},
+		Connections: []PbixConnection{},
+	}
+
+	queryNames := []string{}
+	for _, query := range metadata.Queries {
+		queryNames = append(queryNames, query.Name)
+	}
+	expectedQueryNames := []string{"Names", "ParamOne", "ParamTwo", "Products", "ProductDetails"}
+	if !reflect.DeepEqual(queryNames, expectedQueryNames) {
+		t.Errorf("expected queries %v, got %v", expectedQueryNames, queryNames)
+	}
+	if metadata.Queries[1].Expression != `"ParamOneValue" meta [IsParameterQuery=true, Type="Text", IsParameterQueryRequired=true]` {
+		t.Errorf("unexpected expression for ParamOne %s", metadata.Queries[1].Expression)
+	}
+	metadata.Queries = nil
+
+	if !reflect.DeepEqual(metadata, expected) {
+		t.Errorf("expected\n%+v\ngot\n%+v", expected, metadata)
+	}
+}
+
+func TestInspectPbixFile_live(t *testing.T) {
+	metadata, err := InspectPbixFile("../powerbi/resource_pbix_report_only.pbix")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if metadata.ConnectionType != ConnectionTypeLive {
+		t.Errorf("expected connection type %s, got %s", ConnectionTypeLive, metadata.ConnectionType)
+	}
+	if metadata.DatasetID != "26aaaf81-f175-4bda-b6ee-047c22f5b330" {
+		t.Errorf("expected referenced dataset ID, got %s", metadata.DatasetID)
+	}
+	if len(metadata.Connections) != 1 || metadata.Connections[0].ConnectionType != "pbiServiceLive" {
+		t.Errorf("expected a single live connection, got %+v", metadata.Connections)
+	}
+}
+
+func TestInspectPbixFile_template(t *testing.T) {
+	pbitFile := filepath.Join(t.TempDir(), "template.pbit")
+	writeTestZip(t, pbitFile, map[string]string{
+		"Version": encodeUTF16("1.28"),
+		"DataModelSchema": encodeUTF16(`{"model":{
+			"tables":[
+				{"name":"Sales","partitions":[{"mode":"directQuery"}],"measures":[{"name":"Total","expression":["SUM(Sales[Amount])"]}]},
+				{"name":"Dates","partitions":[{"mode":"import"}]}
+			],
+			"expressions":[
+				{"name":"Server Name","kind":"m","expression":"\"sql.example.com\" meta [IsParameterQuery=true, Type=\"Text\", IsParameterQueryRequired=true]"},
+				{"name":"Top","kind":"m","expression":["10 meta [IsParameterQuery=true, Type=\"Number\"]"]},
+				{"name":"Helper","kind":"m","expression":"let Source = 1 in Source"}
+			]
+		}}`),
+		"Report/Layout": encodeUTF16(`{
+			"config":"{\"bookmarks\":[{\"name\":\"Bookmark1\",\"displayName\":\"First\"},{\"name\":\"Group1\",\"displayName\":\"Group\",\"children\":[{\"name\":\"Bookmark2\",\"displayName\":\"Second\"}]}]}",
+			"sections":[
+				{"name":"ReportSection2","displayName":"Details","ordinal":1,"config":"{\"visibility\":1}","visualContainers":[]},
+				{"name":"ReportSection1","displayName":"Overview","ordinal":0,"config":"{}","visualContainers":[
+					{"x":10,"y":20,"width":300,"height":200,"config":"{\"name\":\"a\",\"singleVisual\":{\"visualType\":\"card\",\"projections\":{\"Values\":[{\"queryRef\":\"Sales.Total\"}],\"Category\":[{\"queryRef\":\"Dates.Year\"}]}}}"},
+					{"config":"{\"name\":\"b\",\"singleVisualGroup\":{\"displayName\":\"Group\"}}"}
+				]}
+			]
+		}`),
+	})
+
+	metadata, err := InspectPbixFile(pbitFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := &PbixMetadata{
+		Version:        "1.28",
+		ConnectionType: ConnectionTypeComposite,
+		Pages: []PbixPage{
+			{Name: "ReportSection1", DisplayName: "Overview", Ordinal: 0, Visuals: []PbixVisual{
+				{Name: "a", Type: "card", X: 10, Y: 20, Width: 300, Height: 200, Fields: []string{"Dates.Year", "Sales.Total"}},
+				{Name: "b", Type: "group", Fields: []string{}},
+			}},
+			{Name: "ReportSection2", DisplayName: "Details", Ordinal: 1, Hidden: true, Visuals: []PbixVisual{}},
+		},
+		Bookmarks: []PbixBookmark{
+			{Name: "Bookmark1", DisplayName: "First"},
+			{Name: "Bookmark2", DisplayName: "Second", Group: "Group"},
+		},
+		Parameters: []PbixParameter{
+			{Name: "Server Name", Value: "sql.example.com", Type: "Text"},
+			{Name: "Top", Value: "10", Type: "Number"},
+		},
+		Queries: []PbixQuery{},
+		Measures: []PbixMeasure{
+			{Table: "Sales", Name: "Total", Expression: "SUM(Sales[Amount])"},
+		},
+		Connections: []PbixConnection{},
+	}
+	if !reflect.DeepEqual(metadata, expected) {
+		t.Errorf("expected\n%+v\ngot\n%+v", expected, metadata)
+	}
+}
+
+func TestInspectPbixFile_invalidLayout(t *testing.T) {
+	pbixFile := filepath.Join(t.TempDir(), "invalid.pbix")
+	writeTestZip(t, pbixFile, map[string]string{
+		"Report/Layout": "not json",
+	})
+
+	if _, err := InspectPbixFile(pbixFile); err == nil {
+		t.Errorf("expected error")
+	}
+}
+
+func encodeUTF16(value string) string {
+	codeUnits := utf16.Encode([]rune(value))
+	data := make([]byte, len(codeUnits)*2)
+	for i, codeUnit := range codeUnits {
+		binary.LittleEndian.PutUint16(data[i*2:], codeUnit)
+	}
+	return string(data)
+}
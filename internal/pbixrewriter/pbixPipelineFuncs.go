@@ -2,6 +2,7 @@ package pbixrewriter
 
 import (
 	"archive/zip"
+	"bytes"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -35,3 +36,49 @@ func SetDatasetIDPipelineFunc(datasetID string) PipelineFunc {
 		return next(file, reader)
 	}
 }
+
+// StripDataPipelineFunc replaces the DataModel of a PBIX, which holds the model together with all
+// imported data, with the given DataModelSchema, which only holds the model definition. The
+// DataModelSchema is usually read from a Power BI template exported from the same PBIX using
+// ReadDataModelSchema. The dataset is empty after import and must be refreshed before it can be used
+func StripDataPipelineFunc(dataModelSchema []byte) PipelineFunc {
+	return func(file *zip.File, reader io.Reader, next PipelineFuncNext) error {
+		if file.Name == "SecurityBindings" {
+			// security bindings are tied to the data model being replaced
+			return nil
+		}
+
+		if file.Name == "DataModel" {
+			schemaFile := *file
+			schemaFile.Name = "DataModelSchema"
+			return next(&schemaFile, bytes.NewReader(dataModelSchema))
+		}
+
+		if file.Name == "[Content_Types].xml" {
+			contentTypes, err := ioutil.ReadAll(reader)
+			if err != nil {
+				return err
+			}
+			contentTypes = bytes.Replace(contentTypes, []byte(`PartName="/DataModel"`), []byte(`PartName="/DataModelSchema"`), 1)
+			return next(file, bytes.NewReader(contentTypes))
+		}
+
+		return next(file, reader)
+	}
+}
+
+// ReadDataModelSchema reads the DataModelSchema from a Power BI template (PBIT)
+func ReadDataModelSchema(pbitFile string) ([]byte, error) {
+	zipReader, err := zip.OpenReader(pbitFile)
+	if err != nil {
+		return nil, err
+	}
+	defer zipReader.Close()
+
+	for _, file := range zipReader.File {
+		if file.Name == "DataModelSchema" {
+			return readZipFile(file)
+		}
+	}
+	return nil, fmt.Errorf("DataModelSchema not found in %s", pbitFile)
+}
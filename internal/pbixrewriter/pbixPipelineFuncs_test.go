@@ -0,0 +1,94 @@
+package pbixrewriter
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStripDataPipelineFunc(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pbixrewriter")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	pbitFile := filepath.Join(dir, "template.pbit")
+	writeTestZip(t, pbitFile, map[string]string{"DataModelSchema": `{"name":"model"}`})
+
+	dataModelSchema, err := ReadDataModelSchema(pbitFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	outputFile := filepath.Join(dir, "output.pbix")
+	err = RewritePbixFiles(samplePbix, outputFile, []PipelineFunc{StripDataPipelineFunc(dataModelSchema)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entries := readTestZip(t, outputFile)
+	if _, ok := entries["DataModel"]; ok {
+		t.Errorf("expected DataModel to be removed")
+	}
+	if _, ok := entries["SecurityBindings"]; ok {
+		t.Errorf("expected SecurityBindings to be removed")
+	}
+	if entries["DataModelSchema"] != `{"name":"model"}` {
+		t.Errorf("expected DataModelSchema to be added, got %q", entries["DataModelSchema"])
+	}
+	if !strings.Contains(entries["[Content_Types].xml"], `PartName="/DataModelSchema"`) || strings.Contains(entries["[Content_Types].xml"], `PartName="/DataModel"`) {
+		t.Errorf("expected content types to reference DataModelSchema, got %s", entries["[Content_Types].xml"])
+	}
+	if _, ok := entries["Report/Layout"]; !ok {
+		t.Errorf("expected other entries to be kept")
+	}
+}
+
+func TestReadDataModelSchema_missing(t *testing.T) {
+	_, err := ReadDataModelSchema(samplePbix)
+	if err == nil {
+		t.Errorf("expected error")
+	}
+}
+
+func writeTestZip(t *testing.T, path string, entries map[string]string) {
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer file.Close()
+
+	zipWriter := zip.NewWriter(file)
+	for name, content := range entries {
+		writer, err := zipWriter.Create(name)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		writer.Write([]byte(content))
+	}
+	if err := zipWriter.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func readTestZip(t *testing.T, path string) map[string]string {
+	zipReader, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer zipReader.Close()
+
+	entries := map[string]string{}
+	for _, file := range zipReader.File {
+		content, err := readZipFile(file)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		entries[file.Name] = string(content)
+	}
+	return entries
+}
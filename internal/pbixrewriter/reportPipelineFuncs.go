@@ -0,0 +1,354 @@
+package pbixrewriter
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const registeredResourcesPath = "Report/StaticResources/RegisteredResources/"
+
+// resourcePackageItemTypeTheme is the type of theme items within the resource packages of the layout
+const resourcePackageItemTypeTheme = 202
+
+var themeFileNameRegex = regexp.MustCompile(`[^A-Za-z0-9]`)
+var contentTypesRegex = regexp.MustCompile(`<Types[^>]*>`)
+
+// SetThemePipelineFunc sets the custom theme of the report within a PBIX, replacing any existing custom theme.
+// The theme is the JSON file exported from Power BI Desktop and must have a name
+func SetThemePipelineFunc(theme []byte) PipelineFunc {
+	var themeObj struct {
+		Name string `json:"name"`
+	}
+	themeErr := json.Unmarshal(theme, &themeObj)
+	if themeErr == nil && themeObj.Name == "" {
+		themeErr = fmt.Errorf("theme does not have a name")
+	}
+	themeFileName := themeFileNameRegex.ReplaceAllString(themeObj.Name, "") + ".json"
+
+	// registered resources may come before the layout, so JSON resources are held back until the
+	// layout shows which of them belong to the themes being replaced
+	var removedThemePaths map[string]bool
+	pendingResources := []pendingEntry{}
+
+	rewriteLayout := rewriteLayoutPipelineFunc(func(layout map[string]interface{}) error {
+		var err error
+		removedThemePaths, err = setLayoutTheme(layout, themeFileName)
+		return err
+	})
+
+	isRemovedResource := func(file *zip.File) bool {
+		path := strings.TrimPrefix(file.Name, registeredResourcesPath)
+		return removedThemePaths[path] || path == themeFileName
+	}
+
+	return func(file *zip.File, reader io.Reader, next PipelineFuncNext) error {
+		if themeErr != nil {
+			return fmt.Errorf("invalid theme: %w", themeErr)
+		}
+
+		if file == EndOfPbix {
+			// reset so the PipelineFunc can be reused for another rewrite
+			defer func() {
+				removedThemePaths = nil
+				pendingResources = []pendingEntry{}
+			}()
+			for _, pending := range pendingResources {
+				if isRemovedResource(pending.file) {
+					continue
+				}
+				if err := next(pending.file, bytes.NewReader(pending.content)); err != nil {
+					return err
+				}
+			}
+			err := next(NewEntry(registeredResourcesPath+themeFileName), bytes.NewReader(theme))
+			if err != nil {
+				return err
+			}
+			return next(file, reader)
+		}
+
+		if strings.HasPrefix(file.Name, registeredResourcesPath) && strings.HasSuffix(strings.ToLower(file.Name), ".json") {
+			if removedThemePaths != nil {
+				if isRemovedResource(file) {
+					return nil
+				}
+				return next(file, reader)
+			}
+			content, err := ioutil.ReadAll(reader)
+			if err != nil {
+				return err
+			}
+			pendingResources = append(pendingResources, pendingEntry{file: file, content: content})
+			return nil
+		}
+
+		if file.Name == "[Content_Types].xml" {
+			contentTypes, err := ioutil.ReadAll(reader)
+			if err != nil {
+				return err
+			}
+			if !bytes.Contains(contentTypes, []byte(`Extension="json"`)) {
+				contentTypes = contentTypesRegex.ReplaceAll(contentTypes, []byte(`$0<Default Extension="json" ContentType="" />`))
+			}
+			return next(file, bytes.NewReader(contentTypes))
+		}
+
+		return rewriteLayout(file, reader, next)
+	}
+}
+
+// pendingEntry is an entry held back by a PipelineFunc until it knows whether to keep it
+type pendingEntry struct {
+	file    *zip.File
+	content []byte
+}
+
+// SetPageVisibilityPipelineFunc hides the given pages of the report within a PBIX and shows all other pages.
+// Pages are identified by either their name or display name
+func SetPageVisibilityPipelineFunc(hiddenPages []string) PipelineFunc {
+	return rewriteLayoutPipelineFunc(func(layout map[string]interface{}) error {
+		sections, err := findLayoutSections(layout, hiddenPages)
+		if err != nil {
+			return err
+		}
+
+		visibleCount := 0
+		for _, section := range layoutSections(layout) {
+			hidden := false
+			for _, hiddenSection := range sections {
+				hidden = hidden || isSameSection(section, hiddenSection)
+			}
+			if !hidden {
+				visibleCount++
+			}
+
+			err := rewriteLayoutConfig(section, func(config map[string]interface{}) {
+				if hidden {
+					config["visibility"] = 1
+				} else {
+					delete(config, "visibility")
+				}
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if visibleCount == 0 {
+			return fmt.Errorf("at least one page must be visible")
+		}
+		return nil
+	})
+}
+
+// RemovePagesPipelineFunc removes the given pages from the report within a PBIX. Pages are identified by either
+// their name or display name. Bookmarks and drillthrough targets referring to removed pages are not updated
+func RemovePagesPipelineFunc(pages []string) PipelineFunc {
+	return rewriteLayoutPipelineFunc(func(layout map[string]interface{}) error {
+		removedSections, err := findLayoutSections(layout, pages)
+		if err != nil {
+			return err
+		}
+
+		sections := []interface{}{}
+		for _, section := range layoutSections(layout) {
+			removed := false
+			for _, removedSection := range removedSections {
+				removed = removed || isSameSection(section, removedSection)
+			}
+			if !removed {
+				sections = append(sections, section)
+			}
+		}
+		if len(sections) == 0 {
+			return fmt.Errorf("at least one page must remain in the report")
+		}
+
+		// ordinals are kept contiguous so pages keep their relative order
+		sort.SliceStable(sections, func(i, j int) bool {
+			return layoutOrdinal(sections[i]) < layoutOrdinal(sections[j])
+		})
+		for i, section := range sections {
+			section.(map[string]interface{})["ordinal"] = i
+		}
+		layout["sections"] = sections
+
+		return rewriteLayoutConfig(layout, func(config map[string]interface{}) {
+			config["activeSectionIndex"] = 0
+		})
+	})
+}
+
+func rewriteLayoutPipelineFunc(rewrite func(layout map[string]interface{}) error) PipelineFunc {
+	return func(file *zip.File, reader io.Reader, next PipelineFuncNext) error {
+		if file.Name != "Report/Layout" {
+			return next(file, reader)
+		}
+
+		data, err := ioutil.ReadAll(reader)
+		if err != nil {
+			return err
+		}
+		text, isUTF16 := decodePbixText(data)
+
+		var layout map[string]interface{}
+		if err := unmarshalLayoutJSON(text, &layout); err != nil {
+			return fmt.Errorf("invalid layout: %w", err)
+		}
+
+		if err := rewrite(layout); err != nil {
+			return err
+		}
+
+		text, err = marshalLayoutJSON(layout)
+		if err != nil {
+			return err
+		}
+		return next(file, bytes.NewReader(encodePbixText(text, isUTF16)))
+	}
+}
+
+// setLayoutTheme replaces the theme items of the layout with the given theme, returning the paths
+// of the theme items that were removed
+func setLayoutTheme(layout map[string]interface{}, themeFileName string) (map[string]bool, error) {
+	themeItem := map[string]interface{}{
+		"type": resourcePackageItemTypeTheme,
+		"path": themeFileName,
+		"name": themeFileName,
+	}
+
+	removedPaths := map[string]bool{}
+	resourcePackages, _ := layout["resourcePackages"].([]interface{})
+	found := false
+	for _, resourcePackage := range resourcePackages {
+		resourcePackage, _ := resourcePackage.(map[string]interface{})["resourcePackage"].(map[string]interface{})
+		if resourcePackage == nil || resourcePackage["name"] != "RegisteredResources" {
+			continue
+		}
+		found = true
+
+		items := []interface{}{}
+		existingItems, _ := resourcePackage["items"].([]interface{})
+		for _, item := range existingItems {
+			itemType, _ := item.(map[string]interface{})["type"].(json.Number)
+			if itemType.String() != fmt.Sprint(resourcePackageItemTypeTheme) {
+				items = append(items, item)
+				continue
+			}
+			if path, ok := item.(map[string]interface{})["path"].(string); ok {
+				removedPaths[path] = true
+			}
+		}
+		resourcePackage["items"] = append(items, themeItem)
+	}
+	if !found {
+		layout["resourcePackages"] = append(resourcePackages, map[string]interface{}{
+			"resourcePackage": map[string]interface{}{
+				"name":     "RegisteredResources",
+				"type":     1,
+				"items":    []interface{}{themeItem},
+				"disabled": false,
+			},
+		})
+	}
+
+	err := rewriteLayoutConfig(layout, func(config map[string]interface{}) {
+		themeCollection, _ := config["themeCollection"].(map[string]interface{})
+		if themeCollection == nil {
+			themeCollection = map[string]interface{}{}
+			config["themeCollection"] = themeCollection
+		}
+
+		// the custom theme uses the same theme schema version as the base theme
+		version := interface{}("5.5")
+		if baseTheme, ok := themeCollection["baseTheme"].(map[string]interface{}); ok && baseTheme["version"] != nil {
+			version = baseTheme["version"]
+		}
+		themeCollection["customTheme"] = map[string]interface{}{
+			"name":    themeFileName,
+			"version": version,
+			"type":    1,
+		}
+	})
+	return removedPaths, err
+}
+
+func layoutSections(layout map[string]interface{}) []interface{} {
+	sections, _ := layout["sections"].([]interface{})
+	return sections
+}
+
+func layoutOrdinal(section interface{}) int64 {
+	ordinal, _ := section.(map[string]interface{})["ordinal"].(json.Number)
+	value, _ := ordinal.Int64()
+	return value
+}
+
+func isSameSection(a interface{}, b interface{}) bool {
+	return a.(map[string]interface{})["name"] == b.(map[string]interface{})["name"]
+}
+
+// findLayoutSections returns the sections matching the given names or display names, failing if any are not found
+func findLayoutSections(layout map[string]interface{}, names []string) ([]interface{}, error) {
+	found := []interface{}{}
+	for _, name := range names {
+		var match interface{}
+		for _, section := range layoutSections(layout) {
+			sectionObj := section.(map[string]interface{})
+			if sectionObj["name"] == name || sectionObj["displayName"] == name {
+				match = section
+				break
+			}
+		}
+		if match == nil {
+			return nil, fmt.Errorf("page %s not found in report", name)
+		}
+		found = append(found, match)
+	}
+	return found, nil
+}
+
+// rewriteLayoutConfig rewrites the JSON encoded config string nested within a layout object
+func rewriteLayoutConfig(obj interface{}, rewrite func(config map[string]interface{})) error {
+	objMap := obj.(map[string]interface{})
+
+	config := map[string]interface{}{}
+	if configText, _ := objMap["config"].(string); configText != "" {
+		if err := unmarshalLayoutJSON(configText, &config); err != nil {
+			return fmt.Errorf("invalid layout config: %w", err)
+		}
+	}
+
+	rewrite(config)
+
+	configText, err := marshalLayoutJSON(config)
+	if err != nil {
+		return err
+	}
+	objMap["config"] = configText
+	return nil
+}
+
+// unmarshalLayoutJSON keeps numbers as written so that unchanged values are not reformatted
+func unmarshalLayoutJSON(text string, v interface{}) error {
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+func marshalLayoutJSON(v interface{}) (string, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buffer.String(), "\n"), nil
+}
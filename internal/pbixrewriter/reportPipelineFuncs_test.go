@@ -0,0 +1,222 @@
+package pbixrewriter
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSetThemePipelineFunc(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "output.pbix")
+	theme := `{"name":"Corporate Theme 2","dataColors":["#123456"]}`
+
+	// applying the theme twice checks the previous custom theme is replaced
+	for i := 0; i < 2; i++ {
+		inputFile := samplePbix
+		if i > 0 {
+			inputFile = outputFile
+		}
+		err := RewritePbixFiles(inputFile, outputFile, []PipelineFunc{SetThemePipelineFunc([]byte(theme))})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	entries := readTestZip(t, outputFile)
+	if entries[registeredResourcesPath+"CorporateTheme2.json"] != theme {
+		t.Errorf("expected theme to be added, got %q", entries[registeredResourcesPath+"CorporateTheme2.json"])
+	}
+
+	layoutText, isUTF16 := decodePbixText([]byte(entries["Report/Layout"]))
+	if !isUTF16 {
+		t.Errorf("expected layout to remain UTF-16")
+	}
+	var layout struct {
+		Config           string
+		ResourcePackages []struct {
+			ResourcePackage struct {
+				Name  string
+				Items []map[string]interface{}
+			}
+		}
+	}
+	if err := json.Unmarshal([]byte(layoutText), &layout); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	registeredItems := []map[string]interface{}{}
+	for _, resourcePackage := range layout.ResourcePackages {
+		if resourcePackage.ResourcePackage.Name == "RegisteredResources" {
+			registeredItems = append(registeredItems, resourcePackage.ResourcePackage.Items...)
+		}
+	}
+	expectedItems := []map[string]interface{}{
+		{"type": float64(202), "path": "CorporateTheme2.json", "name": "CorporateTheme2.json"},
+	}
+	if !reflect.DeepEqual(registeredItems, expectedItems) {
+		t.Errorf("expected registered resources %v, got %v", expectedItems, registeredItems)
+	}
+
+	var config struct {
+		ThemeCollection map[string]map[string]interface{}
+	}
+	if err := json.Unmarshal([]byte(layout.Config), &config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedTheme := map[string]interface{}{"name": "CorporateTheme2.json", "version": "5.5", "type": float64(1)}
+	if !reflect.DeepEqual(config.ThemeCollection["customTheme"], expectedTheme) {
+		t.Errorf("expected custom theme %v, got %v", expectedTheme, config.ThemeCollection["customTheme"])
+	}
+	if config.ThemeCollection["baseTheme"]["name"] != "CY19SU06" {
+		t.Errorf("expected base theme to be unchanged, got %v", config.ThemeCollection["baseTheme"])
+	}
+}
+
+func TestSetThemePipelineFunc_registeredResources(t *testing.T) {
+	layout := encodeUTF16(`{
+		"config":"{}",
+		"resourcePackages":[{"resourcePackage":{"name":"RegisteredResources","type":1,"items":[
+			{"type":202,"path":"OldTheme.json","name":"OldTheme.json"},
+			{"type":300,"path":"ShapeMap.json","name":"ShapeMap.json"},
+			{"type":100,"path":"Logo.png","name":"Logo.png"}
+		]}}],
+		"sections":[]
+	}`)
+	entries := [][2]string{
+		{registeredResourcesPath + "OldTheme.json", `{"name":"Old Theme"}`},
+		{registeredResourcesPath + "ShapeMap.json", `{"type":"Topology"}`},
+		{registeredResourcesPath + "Logo.png", "png"},
+	}
+
+	// the registered resources are checked both before and after the layout
+	for _, layoutFirst := range []bool{true, false} {
+		directory := t.TempDir()
+		inputFile := filepath.Join(directory, "input.pbix")
+		outputFile := filepath.Join(directory, "output.pbix")
+
+		orderedEntries := append([][2]string{}, entries...)
+		if layoutFirst {
+			orderedEntries = append([][2]string{{"Report/Layout", layout}}, orderedEntries...)
+		} else {
+			orderedEntries = append(orderedEntries, [2]string{"Report/Layout", layout})
+		}
+		file, err := os.Create(inputFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		zipWriter := zip.NewWriter(file)
+		for _, entry := range orderedEntries {
+			writer, err := zipWriter.Create(entry[0])
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			writer.Write([]byte(entry[1]))
+		}
+		if err := zipWriter.Close(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		file.Close()
+
+		err = RewritePbixFiles(inputFile, outputFile, []PipelineFunc{SetThemePipelineFunc([]byte(`{"name":"New Theme"}`))})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		outputEntries := readTestZip(t, outputFile)
+		for name, expected := range map[string]bool{"OldTheme.json": false, "ShapeMap.json": true, "Logo.png": true, "NewTheme.json": true} {
+			if _, ok := outputEntries[registeredResourcesPath+name]; ok != expected {
+				t.Errorf("expected %s to be kept %v when layout first is %v", name, expected, layoutFirst)
+			}
+		}
+	}
+}
+
+func TestSetThemePipelineFunc_invalid(t *testing.T) {
+	for _, theme := range []string{"not json", `{"dataColors":[]}`} {
+		outputFile := filepath.Join(t.TempDir(), "output.pbix")
+		err := RewritePbixFiles(samplePbix, outputFile, []PipelineFunc{SetThemePipelineFunc([]byte(theme))})
+		if err == nil {
+			t.Errorf("expected error for theme %s", theme)
+		}
+	}
+}
+
+func TestSetPageVisibilityPipelineFunc(t *testing.T) {
+	metadata := rewriteTestPages(t, SetPageVisibilityPipelineFunc([]string{"Details", "ReportSection3"}))
+
+	hidden := map[string]bool{}
+	for _, page := range metadata.Pages {
+		hidden[page.Name] = page.Hidden
+	}
+	expected := map[string]bool{"ReportSection1": false, "ReportSection2": true, "ReportSection3": true}
+	if !reflect.DeepEqual(hidden, expected) {
+		t.Errorf("expected hidden pages %v, got %v", expected, hidden)
+	}
+}
+
+func TestRemovePagesPipelineFunc(t *testing.T) {
+	metadata := rewriteTestPages(t, RemovePagesPipelineFunc([]string{"Overview"}))
+
+	expected := []PbixPage{
+		{Name: "ReportSection2", DisplayName: "Details", Ordinal: 0, Visuals: []PbixVisual{}},
+		{Name: "ReportSection3", DisplayName: "Appendix", Ordinal: 1, Hidden: true, Visuals: []PbixVisual{}},
+	}
+	if !reflect.DeepEqual(metadata.Pages, expected) {
+		t.Errorf("expected pages %+v, got %+v", expected, metadata.Pages)
+	}
+}
+
+func TestPagePipelineFuncs_errors(t *testing.T) {
+	pipelineFuncs := map[string]PipelineFunc{
+		"missing page":      SetPageVisibilityPipelineFunc([]string{"Missing"}),
+		"all pages hidden":  SetPageVisibilityPipelineFunc([]string{"Overview", "Details", "Appendix"}),
+		"all pages removed": RemovePagesPipelineFunc([]string{"Overview", "Details", "Appendix"}),
+	}
+
+	for name, pipelineFunc := range pipelineFuncs {
+		t.Run(name, func(t *testing.T) {
+			directory := t.TempDir()
+			inputFile := filepath.Join(directory, "input.pbix")
+			writeTestPagesZip(t, inputFile)
+
+			err := RewritePbixFiles(inputFile, filepath.Join(directory, "output.pbix"), []PipelineFunc{pipelineFunc})
+			if err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+}
+
+func rewriteTestPages(t *testing.T, pipelineFunc PipelineFunc) *PbixMetadata {
+	directory := t.TempDir()
+	inputFile := filepath.Join(directory, "input.pbix")
+	outputFile := filepath.Join(directory, "output.pbix")
+	writeTestPagesZip(t, inputFile)
+
+	err := RewritePbixFiles(inputFile, outputFile, []PipelineFunc{pipelineFunc})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	metadata, err := InspectPbixFile(outputFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return metadata
+}
+
+func writeTestPagesZip(t *testing.T, path string) {
+	writeTestZip(t, path, map[string]string{
+		"Report/Layout": encodeUTF16(`{
+			"config":"{\"activeSectionIndex\":2}",
+			"sections":[
+				{"name":"ReportSection1","displayName":"Overview","ordinal":0,"config":"{}","visualContainers":[]},
+				{"name":"ReportSection3","displayName":"Appendix","ordinal":2,"config":"{\"visibility\":1}","visualContainers":[]},
+				{"name":"ReportSection2","displayName":"Details","ordinal":1,"config":"{}","visualContainers":[]}
+			]
+		}`),
+	})
+}
@@ -2,34 +2,58 @@ package pbixrewriter
 
 import (
 	"archive/zip"
+	"bytes"
+	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"time"
 )
 
-// PipelineFunc defines a unit of processing for rewriting PBIX files
+// PipelineFunc defines a unit of processing for rewriting PBIX files. Entries are removed by not
+// calling next, and added by calling next more than once. Each PipelineFunc is called once more
+// with EndOfPbix after the last entry so entries can be appended
 type PipelineFunc func(file *zip.File, reader io.Reader, next PipelineFuncNext) error
 
 // PipelineFuncNext defines the next function inside a PipelineFunc
 type PipelineFuncNext func(file *zip.File, reader io.Reader) error
 
-// RewritePbix rewrites a PBIX file applying the given PipelineFuncs
+// EndOfPbix is passed through the pipeline after the last entry of the PBIX. It is never
+// written to the output and must be passed on to next so later PipelineFuncs also see it
+var EndOfPbix = &zip.File{}
+
+// RewritePbix rewrites a PBIX file applying the given PipelineFuncs. Entries keep the compression
+// method and modified time of the entry they were read from
 func RewritePbix(input *zip.Reader, output *zip.Writer, pipelineFuncs []PipelineFunc) error {
+	pipeline := nestPipelineFunc(0, append(pipelineFuncs[:len(pipelineFuncs):len(pipelineFuncs)], buildWriterPipelineFunc(output)))
+
 	for _, inputItem := range input.File {
-		inputItemReader, err := inputItem.Open()
+		err := rewritePbixEntry(inputItem, pipeline)
 		if err != nil {
-			return err
+			return fmt.Errorf("failed to rewrite %s: %w", inputItem.Name, err)
 		}
-		defer inputItemReader.Close()
-
-		pipeline := nestPipelineFunc(0, append(pipelineFuncs, buildWriterPipelineFunc(output)))
-
-		pipeline(inputItem, inputItemReader)
+	}
 
+	err := pipeline(EndOfPbix, bytes.NewReader(nil))
+	if err != nil {
+		return fmt.Errorf("failed to add entries: %w", err)
 	}
 	return nil
 }
 
-// RewritePbixFiles rewrites a PBIX file applying the given PipelineFuncs
+func rewritePbixEntry(inputItem *zip.File, pipeline PipelineFuncNext) error {
+	inputItemReader, err := inputItem.Open()
+	if err != nil {
+		return err
+	}
+	defer inputItemReader.Close()
+
+	return pipeline(inputItem, inputItemReader)
+}
+
+// RewritePbixFiles rewrites a PBIX file applying the given PipelineFuncs. The output is written to a
+// temporary file which replaces outputPbixFile once complete, so a failed rewrite leaves no partial output
 func RewritePbixFiles(inputPbixFile string, outputPbixFile string, pipelineFuncs []PipelineFunc) error {
 	zipReader, err := zip.OpenReader(inputPbixFile)
 	if err != nil {
@@ -37,26 +61,88 @@ func RewritePbixFiles(inputPbixFile string, outputPbixFile string, pipelineFuncs
 	}
 	defer zipReader.Close()
 
-	targetFile, err := os.Create(outputPbixFile)
+	targetFile, err := ioutil.TempFile(filepath.Dir(outputPbixFile), filepath.Base(outputPbixFile)+".*.tmp")
 	if err != nil {
 		return err
 	}
+	defer os.Remove(targetFile.Name())
+	defer targetFile.Close()
+
 	targetZipWriter := zip.NewWriter(targetFile)
-	defer targetZipWriter.Close()
+	err = RewritePbix(&zipReader.Reader, targetZipWriter, pipelineFuncs)
+	if err != nil {
+		return err
+	}
+	err = targetZipWriter.Close()
+	if err != nil {
+		return err
+	}
+	err = targetFile.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(targetFile.Name(), outputPbixFile)
+}
+
+// NewEntry returns a file that can be passed to next to add an entry to the PBIX
+func NewEntry(name string) *zip.File {
+	file := zip.File{}
+	file.Name = name
+	file.Method = zip.Deflate
+	file.Modified = time.Now()
+	return &file
+}
+
+// AddEntryPipelineFunc adds an entry to the PBIX, replacing the content of the entry if it already exists
+func AddEntryPipelineFunc(name string, content []byte) PipelineFunc {
+	found := false
+	return func(file *zip.File, reader io.Reader, next PipelineFuncNext) error {
+		if file == EndOfPbix {
+			// reset so the PipelineFunc can be reused for another rewrite
+			defer func() { found = false }()
+			if !found {
+				err := next(NewEntry(name), bytes.NewReader(content))
+				if err != nil {
+					return err
+				}
+			}
+			return next(file, reader)
+		}
 
-	return RewritePbix(&zipReader.Reader, targetZipWriter, pipelineFuncs)
+		if file.Name == name {
+			found = true
+			return next(file, bytes.NewReader(content))
+		}
+		return next(file, reader)
+	}
+}
+
+// DeleteEntryPipelineFunc removes an entry from the PBIX
+func DeleteEntryPipelineFunc(name string) PipelineFunc {
+	return func(file *zip.File, reader io.Reader, next PipelineFuncNext) error {
+		if file.Name == name {
+			return nil
+		}
+		return next(file, reader)
+	}
 }
 
 func buildWriterPipelineFunc(writer *zip.Writer) PipelineFunc {
 
 	return func(file *zip.File, reader io.Reader, next PipelineFuncNext) error {
-		header, err := zip.FileInfoHeader(file.FileInfo())
-		if err != nil {
-			return err
+		if file == EndOfPbix {
+			return nil
 		}
-		header.Name = file.Name
 
-		outputItemWriter, err := writer.CreateHeader(header)
+		// sizes and checksums are recalculated when writing, extra fields are dropped as they may hold stale zip64 sizes
+		header := file.FileHeader
+		header.Extra = nil
+		header.CRC32 = 0
+		header.CompressedSize64 = 0
+		header.UncompressedSize64 = 0
+
+		outputItemWriter, err := writer.CreateHeader(&header)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,128 @@
+package pbixrewriter
+
+import (
+	"archive/zip"
+	"errors"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRewritePbixFiles_preservesEntries(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "output.pbix")
+
+	err := RewritePbixFiles(samplePbix, outputFile, []PipelineFunc{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	input, err := zip.OpenReader(samplePbix)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer input.Close()
+	output, err := zip.OpenReader(outputFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer output.Close()
+
+	if len(input.File) != len(output.File) {
+		t.Fatalf("expected %d entries, got %d", len(input.File), len(output.File))
+	}
+	for i, inputFile := range input.File {
+		outputFile := output.File[i]
+		if inputFile.Name != outputFile.Name {
+			t.Errorf("expected entry %s, got %s", inputFile.Name, outputFile.Name)
+		}
+		if inputFile.Method != outputFile.Method {
+			t.Errorf("expected %s to have method %d, got %d", inputFile.Name, inputFile.Method, outputFile.Method)
+		}
+		if !inputFile.Modified.Equal(outputFile.Modified) {
+			t.Errorf("expected %s to be modified at %s, got %s", inputFile.Name, inputFile.Modified, outputFile.Modified)
+		}
+		if inputFile.CRC32 != outputFile.CRC32 {
+			t.Errorf("expected %s to be unchanged", inputFile.Name)
+		}
+	}
+}
+
+func TestRewritePbixFiles_addAndDeleteEntries(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "output.pbix")
+
+	addEntry := AddEntryPipelineFunc("Added", []byte("added"))
+	err := RewritePbixFiles(samplePbix, outputFile, []PipelineFunc{
+		DeleteEntryPipelineFunc("SecurityBindings"),
+		AddEntryPipelineFunc("Settings", []byte("replaced")),
+		addEntry,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entries := readTestZip(t, outputFile)
+	if _, ok := entries["SecurityBindings"]; ok {
+		t.Errorf("expected SecurityBindings to be deleted")
+	}
+	if entries["Settings"] != "replaced" {
+		t.Errorf("expected Settings to be replaced, got %q", entries["Settings"])
+	}
+	if entries["Added"] != "added" {
+		t.Errorf("expected Added to be added, got %q", entries["Added"])
+	}
+	if len(entries) != 10 {
+		t.Errorf("expected 10 entries, got %d", len(entries))
+	}
+
+	// the same PipelineFunc adds the entry again when reused
+	err = RewritePbixFiles(samplePbix, outputFile, []PipelineFunc{addEntry})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entries := readTestZip(t, outputFile); entries["Added"] != "added" {
+		t.Errorf("expected Added to be added, got %q", entries["Added"])
+	}
+}
+
+func TestRewritePbixFiles_error(t *testing.T) {
+	directory := t.TempDir()
+	outputFile := filepath.Join(directory, "output.pbix")
+	if err := ioutil.WriteFile(outputFile, []byte("existing"), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := RewritePbixFiles(samplePbix, outputFile, []PipelineFunc{
+		func(file *zip.File, reader io.Reader, next PipelineFuncNext) error {
+			if file.Name == "DataMashup" {
+				return errors.New("pipeline failed")
+			}
+			return next(file, reader)
+		},
+	})
+	if err == nil || !strings.Contains(err.Error(), "DataMashup") {
+		t.Errorf("expected error naming the entry, got %v", err)
+	}
+
+	content, err := ioutil.ReadFile(outputFile)
+	if err != nil || string(content) != "existing" {
+		t.Errorf("expected existing output to be unchanged, got %q %v", content, err)
+	}
+	files, _ := filepath.Glob(filepath.Join(directory, "*"))
+	if !reflect.DeepEqual(files, []string{outputFile}) {
+		t.Errorf("expected temporary files to be removed, got %v", files)
+	}
+}
+
+func TestNewEntry(t *testing.T) {
+	entry := NewEntry("Report/Layout")
+	if entry.Name != "Report/Layout" || entry.Method != zip.Deflate {
+		t.Errorf("unexpected entry %+v", entry.FileHeader)
+	}
+	if time.Since(entry.Modified) > time.Minute {
+		t.Errorf("expected entry to be modified now, got %s", entry.Modified)
+	}
+}
@@ -10,6 +10,8 @@ import (
 // DataSourceApp returns a specific Power BI app
 func DataSourceApp() *schema.Resource {
 	return &schema.Resource{
+		Description: "returns a Power BI app installed for the user.",
+
 		Read: dataSourceAppRead,
 
 		Schema: map[string]*schema.Schema{
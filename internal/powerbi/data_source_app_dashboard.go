@@ -10,6 +10,8 @@ import (
 // DataSourceAppDashboard returns dashboards from a Power BI app
 func DataSourceAppDashboard() *schema.Resource {
 	return &schema.Resource{
+		Description: "returns the dashboards within a Power BI app.",
+
 		Read: dataSourceAppDashboardRead,
 
 		Schema: map[string]*schema.Schema{
@@ -10,6 +10,8 @@ import (
 // DataSourceAppReport returns reports from a Power BI app
 func DataSourceAppReport() *schema.Resource {
 	return &schema.Resource{
+		Description: "returns the reports within a Power BI app.",
+
 		Read: dataSourceAppReportRead,
 
 		Schema: map[string]*schema.Schema{
@@ -10,6 +10,8 @@ import (
 // DataSourceDataflow returns a specific dataflow from a workspace
 func DataSourceDataflow() *schema.Resource {
 	return &schema.Resource{
+		Description: "returns a dataflow within a workspace.",
+
 		Read: dataSourceDataflowRead,
 
 		Schema: map[string]*schema.Schema{
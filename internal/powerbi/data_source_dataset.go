@@ -0,0 +1,290 @@
+package powerbi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/codecutout/terraform-provider-powerbi/internal/powerbiapi"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// DataSourceDataset represents a Power BI dataset and its model metadata
+func DataSourceDataset() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDatasetRead,
+
+		Schema: map[string]*schema.Schema{
+			"workspace_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the workspace containing the dataset.",
+			},
+			"dataset_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"dataset_id", "name"},
+				Description:  "ID of the dataset.",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"dataset_id", "name"},
+				Description:  "Name of the dataset. The name must be unique within the workspace.",
+			},
+			"configured_by": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Owner of the dataset.",
+			},
+			"is_refreshable": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the dataset can be refreshed.",
+			},
+			"target_storage_mode": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Storage mode of the dataset.",
+			},
+			"web_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Web URL of the dataset.",
+			},
+			"parameters": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Parameters defined on the dataset.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the parameter.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the parameter.",
+						},
+						"is_required": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the parameter is required.",
+						},
+						"current_value": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Current value of the parameter.",
+						},
+					},
+				},
+			},
+			"datasources": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Datasources used by the dataset.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"datasource_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the datasource.",
+						},
+						"gateway_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the gateway the datasource is bound to.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the datasource.",
+						},
+						"server": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Server name of the datasource.",
+						},
+						"database": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Database name of the datasource.",
+						},
+						"url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Service URL of the datasource.",
+						},
+					},
+				},
+			},
+			"refresh_schedule": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Refresh schedule of the dataset. Empty if the dataset cannot be refreshed.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the refresh schedule is enabled.",
+						},
+						"days": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Days on which the dataset is refreshed.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"times": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Times of day at which the dataset is refreshed.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"local_time_zone_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Time zone of the refresh times.",
+						},
+						"notify_option": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Notification option on refresh failure.",
+						},
+					},
+				},
+			},
+			"upstream_dataflows": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Dataflows the dataset gets data from.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"dataflow_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the dataflow.",
+						},
+						"workspace_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the workspace containing the dataflow.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDatasetRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+	datasetID := d.Get("dataset_id").(string)
+
+	if datasetID == "" {
+		name := d.Get("name").(string)
+		datasets, err := client.GetDatasetsInGroup(groupID)
+		if err != nil {
+			return fmt.Errorf("failed to get datasets in workspace %s: %w", groupID, err)
+		}
+		for _, dataset := range datasets.Value {
+			if dataset.Name == name {
+				if datasetID != "" {
+					return fmt.Errorf("multiple datasets named %s found in workspace %s", name, groupID)
+				}
+				datasetID = dataset.ID
+			}
+		}
+		if datasetID == "" {
+			return fmt.Errorf("dataset %s not found in workspace %s", name, groupID)
+		}
+	}
+
+	dataset, err := client.GetDatasetInGroup(groupID, datasetID)
+	if err != nil {
+		return fmt.Errorf("failed to get dataset %s: %w", datasetID, err)
+	}
+
+	parameters, err := client.GetParametersInGroup(groupID, datasetID)
+	if err != nil {
+		return fmt.Errorf("failed to get parameters of dataset %s: %w", datasetID, err)
+	}
+	parameterList := make([]interface{}, 0, len(parameters.Value))
+	for _, parameter := range parameters.Value {
+		parameterList = append(parameterList, map[string]interface{}{
+			"name":          parameter.Name,
+			"type":          parameter.Type,
+			"is_required":   parameter.IsRequired,
+			"current_value": parameter.CurrentValue,
+		})
+	}
+
+	datasources, err := client.GetDatasourcesInGroup(groupID, datasetID)
+	if err != nil {
+		return fmt.Errorf("failed to get datasources of dataset %s: %w", datasetID, err)
+	}
+	datasourceList := make([]interface{}, 0, len(datasources.Value))
+	for _, datasource := range datasources.Value {
+		datasourceList = append(datasourceList, map[string]interface{}{
+			"datasource_id": datasource.DatasourceID,
+			"gateway_id":    datasource.GatewayID,
+			"type":          datasource.DatasourceType,
+			"server":        nilToEmptyString(datasource.ConnectionDetails.Server),
+			"database":      nilToEmptyString(datasource.ConnectionDetails.Database),
+			"url":           nilToEmptyString(datasource.ConnectionDetails.URL),
+		})
+	}
+
+	// datasets that cannot be refreshed, such as DirectQuery datasets, have no refresh schedule
+	refreshScheduleList := make([]interface{}, 0, 1)
+	if dataset.IsRefreshable {
+		refreshSchedule, err := client.GetRefreshScheduleInGroup(groupID, datasetID)
+		if err != nil {
+			return fmt.Errorf("failed to get refresh schedule of dataset %s: %w", datasetID, err)
+		}
+		refreshScheduleList = append(refreshScheduleList, map[string]interface{}{
+			"enabled":            refreshSchedule.Enabled,
+			"days":               refreshSchedule.Days,
+			"times":              refreshSchedule.Times,
+			"local_time_zone_id": refreshSchedule.LocalTimeZoneID,
+			"notify_option":      refreshSchedule.NotifyOption,
+		})
+	}
+
+	upstreamDataflows, err := client.GetUpstreamDataflowsInGroup(groupID)
+	if err != nil {
+		return fmt.Errorf("failed to get upstream dataflows in workspace %s: %w", groupID, err)
+	}
+	upstreamDataflowList := make([]interface{}, 0)
+	for _, link := range upstreamDataflows.Value {
+		if strings.EqualFold(link.DatasetObjectID, datasetID) {
+			upstreamDataflowList = append(upstreamDataflowList, map[string]interface{}{
+				"dataflow_id":  link.DataflowObjectID,
+				"workspace_id": link.WorkspaceObjectID,
+			})
+		}
+	}
+
+	d.SetId(dataset.ID)
+	d.Set("dataset_id", dataset.ID)
+	d.Set("name", dataset.Name)
+	d.Set("configured_by", dataset.ConfiguredBy)
+	d.Set("is_refreshable", dataset.IsRefreshable)
+	d.Set("target_storage_mode", dataset.TargetStorageMode)
+	d.Set("web_url", dataset.WebURL)
+	d.Set("parameters", parameterList)
+	d.Set("datasources", datasourceList)
+	d.Set("refresh_schedule", refreshScheduleList)
+	d.Set("upstream_dataflows", upstreamDataflowList)
+
+	return nil
+}
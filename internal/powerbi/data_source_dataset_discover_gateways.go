@@ -0,0 +1,109 @@
+package powerbi
+
+import (
+	"fmt"
+
+	"github.com/codecutout/terraform-provider-powerbi/internal/powerbiapi"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// DataSourceDatasetDiscoverGateways returns the gateways a dataset can be bound to
+func DataSourceDatasetDiscoverGateways() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDatasetDiscoverGatewaysRead,
+
+		Schema: map[string]*schema.Schema{
+			"workspace_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Workspace ID in which the dataset exists.",
+			},
+			"dataset_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the dataset.",
+			},
+			"gateways": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Gateways the dataset can be bound to.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the gateway.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the gateway.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the gateway.",
+						},
+						"gateway_status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Status of the gateway.",
+						},
+						"public_key": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Public key information for the gateway.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"exponent": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Exponent of the public key.",
+									},
+									"modulus": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Modulus of the public key.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDatasetDiscoverGatewaysRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+	datasetID := d.Get("dataset_id").(string)
+
+	gateways, err := client.DiscoverGatewaysInGroup(groupID, datasetID)
+	if err != nil {
+		return fmt.Errorf("failed to discover gateways for dataset %s: %w", datasetID, err)
+	}
+
+	gatewayList := make([]interface{}, 0, len(gateways.Value))
+	for _, gateway := range gateways.Value {
+		gatewayList = append(gatewayList, map[string]interface{}{
+			"id":             gateway.ID,
+			"name":           gateway.Name,
+			"type":           gateway.Type,
+			"gateway_status": gateway.GatewayStatus,
+			"public_key": []interface{}{
+				map[string]interface{}{
+					"exponent": gateway.PublicKey.Exponent,
+					"modulus":  gateway.PublicKey.Modulus,
+				},
+			},
+		})
+	}
+
+	d.SetId(datasetID)
+	d.Set("gateways", gatewayList)
+
+	return nil
+}
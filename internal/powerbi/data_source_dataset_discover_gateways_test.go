@@ -0,0 +1,44 @@
+package powerbi
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDataSourceDatasetDiscoverGateways_basic(t *testing.T) {
+	workspaceSuffix := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerbiWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "powerbi_workspace" "test" {
+					name = "Acceptance Test Workspace %s"
+				}
+
+				resource "powerbi_pbix" "test" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name = "Acceptance Test PBIX"
+					source = "./resource_pbix_test_sample1.pbix"
+					source_hash = "${filemd5("./resource_pbix_test_sample1.pbix")}"
+				}
+
+				data "powerbi_dataset_discover_gateways" "test" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					dataset_id = "${powerbi_pbix.test.dataset_id}"
+				}
+				`, workspaceSuffix),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.powerbi_dataset_discover_gateways.test", "id", "powerbi_pbix.test", "dataset_id"),
+					resource.TestCheckResourceAttrSet("data.powerbi_dataset_discover_gateways.test", "gateways.#"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,56 @@
+package powerbi
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDataSourceDataset_basic(t *testing.T) {
+	workspaceSuffix := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerbiWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "powerbi_workspace" "test" {
+					name = "Acceptance Test Workspace %s"
+				}
+
+				resource "powerbi_pbix" "test" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name = "Acceptance Test PBIX"
+					source = "./resource_pbix_test_sample1.pbix"
+					source_hash = "${filemd5("./resource_pbix_test_sample1.pbix")}"
+				}
+
+				data "powerbi_dataset" "by_id" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					dataset_id = "${powerbi_pbix.test.dataset_id}"
+				}
+
+				data "powerbi_dataset" "by_name" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name = "Acceptance Test PBIX"
+					depends_on = [powerbi_pbix.test]
+				}
+				`, workspaceSuffix),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerbi_dataset.by_id", "name", "Acceptance Test PBIX"),
+					resource.TestCheckResourceAttrSet("data.powerbi_dataset.by_id", "configured_by"),
+					resource.TestCheckResourceAttrPair("data.powerbi_dataset.by_name", "dataset_id", "powerbi_pbix.test", "dataset_id"),
+					resource.TestCheckResourceAttr("data.powerbi_dataset.by_id", "parameters.#", "2"),
+					resource.TestCheckResourceAttr("data.powerbi_dataset.by_id", "datasources.#", "1"),
+					resource.TestCheckResourceAttr("data.powerbi_dataset.by_id", "datasources.0.type", "OData"),
+					resource.TestCheckResourceAttr("data.powerbi_dataset.by_id", "datasources.0.url", "https://services.odata.org/V3/OData/OData.svc"),
+					resource.TestCheckResourceAttr("data.powerbi_dataset.by_id", "upstream_dataflows.#", "0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,126 @@
+package powerbi
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/codecutout/terraform-provider-powerbi/internal/powerbiapi"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// DataSourceDatasets returns the datasets within a workspace
+func DataSourceDatasets() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDatasetsRead,
+
+		Schema: map[string]*schema.Schema{
+			"workspace_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the workspace containing the datasets.",
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				Description:  "Regular expression the dataset name must match.",
+			},
+			"configured_by": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return datasets owned by this user.",
+			},
+			"datasets": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Datasets matching the filters, ordered by name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the dataset.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the dataset.",
+						},
+						"configured_by": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Owner of the dataset.",
+						},
+						"is_refreshable": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the dataset can be refreshed.",
+						},
+						"target_storage_mode": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Storage mode of the dataset.",
+						},
+						"web_url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Web URL of the dataset.",
+						},
+						"create_report_embed_url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Embed URL used to create a report from the dataset.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDatasetsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+	configuredBy := d.Get("configured_by").(string)
+
+	nameRegex, err := regexp.Compile(d.Get("name_regex").(string))
+	if err != nil {
+		return err
+	}
+
+	datasets, err := client.GetDatasetsInGroupWithPagination(groupID, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get datasets in workspace %s: %w", groupID, err)
+	}
+	sort.SliceStable(datasets.Value, func(i, j int) bool {
+		return datasets.Value[i].Name < datasets.Value[j].Name
+	})
+
+	datasetList := make([]interface{}, 0, len(datasets.Value))
+	for _, dataset := range datasets.Value {
+		if !nameRegex.MatchString(dataset.Name) {
+			continue
+		}
+		if configuredBy != "" && configuredBy != dataset.ConfiguredBy {
+			continue
+		}
+
+		datasetList = append(datasetList, map[string]interface{}{
+			"id":                      dataset.ID,
+			"name":                    dataset.Name,
+			"configured_by":           dataset.ConfiguredBy,
+			"is_refreshable":          dataset.IsRefreshable,
+			"target_storage_mode":     dataset.TargetStorageMode,
+			"web_url":                 dataset.WebURL,
+			"create_report_embed_url": dataset.CreateReportEmbedURL,
+		})
+	}
+
+	d.SetId(groupID)
+	d.Set("datasets", datasetList)
+
+	return nil
+}
@@ -12,6 +12,8 @@ import (
 // DataSourceEmbedToken generates embed tokens for Power BI content
 func DataSourceEmbedToken() *schema.Resource {
 	return &schema.Resource{
+		Description: "generates an embed token for Power BI content.",
+
 		Read: dataSourceEmbedTokenRead,
 
 		Schema: map[string]*schema.Schema{
@@ -0,0 +1,194 @@
+package powerbi
+
+import (
+	"fmt"
+
+	"github.com/codecutout/terraform-provider-powerbi/internal/pbixrewriter"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// DataSourcePBIXMetadata returns the contents of a local PBIX file
+func DataSourcePBIXMetadata() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourcePBIXMetadataRead,
+
+		Schema: map[string]*schema.Schema{
+			"source": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "An absolute path to a PBIX or PBIT file on the local system.",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The file format version of the PBIX.",
+			},
+			"connection_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "How the report gets its data. Any of: `None`, `Import`, `DirectQuery`, `Composite`, `Live`, `Unknown`. `None` and `Live` are detected for any file. `Import`, `DirectQuery` and `Composite` are read from the storage mode of the tables, which is only readable from PBIT files, so a PBIX with its own data model is always reported as `Unknown`.",
+			},
+			"dataset_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the dataset the report is live connected to, or the dataset the PBIX was last published to. Empty if the PBIX has not been published.",
+			},
+			"pages": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Pages of the report, in the order they appear in the report.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Internal name of the page. This is the name used when exporting or embedding a page.",
+						},
+						"display_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Display name of the page.",
+						},
+						"order": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Position of the page within the report.",
+						},
+						"hidden": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the page is hidden in the report.",
+						},
+						"visuals": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Visuals on the page.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Internal name of the visual.",
+									},
+									"type": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Type of the visual, such as `tableEx` or `card`. Groups of visuals have the type `group`.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"bookmarks": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Bookmarks of the report. Bookmarks within groups are listed individually.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Internal name of the bookmark.",
+						},
+						"display_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Display name of the bookmark.",
+						},
+						"group": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Display name of the group containing the bookmark. Empty if the bookmark is not in a group.",
+						},
+					},
+				},
+			},
+			"parameters": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Power Query parameters of the PBIX.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the parameter.",
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Current value of the parameter. Text values are unquoted, other values are M expressions.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the parameter, such as `Text` or `Number`.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePBIXMetadataRead(d *schema.ResourceData, meta interface{}) error {
+	source := d.Get("source").(string)
+
+	metadata, err := pbixrewriter.InspectPbixFile(source)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", source, err)
+	}
+
+	hash, err := hashPBIXFile(source, false)
+	if err != nil {
+		return err
+	}
+
+	pageList := make([]interface{}, 0, len(metadata.Pages))
+	for _, page := range metadata.Pages {
+		visualList := make([]interface{}, 0, len(page.Visuals))
+		for _, visual := range page.Visuals {
+			visualList = append(visualList, map[string]interface{}{
+				"name": visual.Name,
+				"type": visual.Type,
+			})
+		}
+		pageList = append(pageList, map[string]interface{}{
+			"name":         page.Name,
+			"display_name": page.DisplayName,
+			"order":        page.Ordinal,
+			"hidden":       page.Hidden,
+			"visuals":      visualList,
+		})
+	}
+
+	bookmarkList := make([]interface{}, 0, len(metadata.Bookmarks))
+	for _, bookmark := range metadata.Bookmarks {
+		bookmarkList = append(bookmarkList, map[string]interface{}{
+			"name":         bookmark.Name,
+			"display_name": bookmark.DisplayName,
+			"group":        bookmark.Group,
+		})
+	}
+
+	parameterList := make([]interface{}, 0, len(metadata.Parameters))
+	for _, parameter := range metadata.Parameters {
+		parameterList = append(parameterList, map[string]interface{}{
+			"name":  parameter.Name,
+			"value": parameter.Value,
+			"type":  parameter.Type,
+		})
+	}
+
+	d.SetId(hash)
+	d.Set("version", metadata.Version)
+	d.Set("connection_type", metadata.ConnectionType)
+	d.Set("dataset_id", metadata.DatasetID)
+	d.Set("pages", pageList)
+	d.Set("bookmarks", bookmarkList)
+	d.Set("parameters", parameterList)
+
+	return nil
+}
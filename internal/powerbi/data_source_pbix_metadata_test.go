@@ -0,0 +1,41 @@
+package powerbi
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDataSourcePBIXMetadata_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "powerbi_pbix_metadata" "sample1" {
+					source = "./resource_pbix_test_sample1.pbix"
+				}
+
+				data "powerbi_pbix_metadata" "report_only" {
+					source = "./resource_pbix_report_only.pbix"
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerbi_pbix_metadata.sample1", "version", "1.17"),
+					resource.TestCheckResourceAttr("data.powerbi_pbix_metadata.sample1", "connection_type", "Unknown"),
+					resource.TestCheckResourceAttr("data.powerbi_pbix_metadata.sample1", "pages.#", "1"),
+					resource.TestCheckResourceAttr("data.powerbi_pbix_metadata.sample1", "pages.0.display_name", "Page 1"),
+					resource.TestCheckResourceAttr("data.powerbi_pbix_metadata.sample1", "pages.0.visuals.#", "2"),
+					resource.TestCheckResourceAttr("data.powerbi_pbix_metadata.sample1", "pages.0.visuals.0.type", "tableEx"),
+					resource.TestCheckResourceAttr("data.powerbi_pbix_metadata.sample1", "bookmarks.#", "0"),
+					resource.TestCheckResourceAttr("data.powerbi_pbix_metadata.sample1", "parameters.#", "2"),
+					resource.TestCheckResourceAttr("data.powerbi_pbix_metadata.sample1", "parameters.0.name", "ParamOne"),
+					resource.TestCheckResourceAttr("data.powerbi_pbix_metadata.sample1", "parameters.0.value", "ParamOneValue"),
+					resource.TestCheckResourceAttr("data.powerbi_pbix_metadata.report_only", "connection_type", "Live"),
+					resource.TestCheckResourceAttr("data.powerbi_pbix_metadata.report_only", "dataset_id", "26aaaf81-f175-4bda-b6ee-047c22f5b330"),
+				),
+			},
+		},
+	})
+}
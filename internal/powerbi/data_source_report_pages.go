@@ -0,0 +1,82 @@
+package powerbi
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/codecutout/terraform-provider-powerbi/internal/powerbiapi"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// DataSourceReportPages returns the pages of a report
+func DataSourceReportPages() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceReportPagesRead,
+
+		Schema: map[string]*schema.Schema{
+			"workspace_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the workspace containing the report.",
+			},
+			"report_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the report.",
+			},
+			"pages": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Pages of the report, in the order they appear in the report.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Internal name of the page. This is the name used when exporting or embedding a page.",
+						},
+						"display_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Display name of the page.",
+						},
+						"order": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Position of the page within the report.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceReportPagesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+	reportID := d.Get("report_id").(string)
+
+	pages, err := client.GetPagesInGroup(groupID, reportID)
+	if err != nil {
+		return fmt.Errorf("failed to get pages of report %s: %w", reportID, err)
+	}
+	sort.SliceStable(pages.Value, func(i, j int) bool {
+		return pages.Value[i].Order < pages.Value[j].Order
+	})
+
+	pageList := make([]interface{}, 0, len(pages.Value))
+	for _, page := range pages.Value {
+		pageList = append(pageList, map[string]interface{}{
+			"name":         page.Name,
+			"display_name": page.DisplayName,
+			"order":        page.Order,
+		})
+	}
+
+	d.SetId(reportID)
+	d.Set("pages", pageList)
+
+	return nil
+}
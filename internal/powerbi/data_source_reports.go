@@ -0,0 +1,121 @@
+package powerbi
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/codecutout/terraform-provider-powerbi/internal/powerbiapi"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// DataSourceReports returns the reports within a workspace
+func DataSourceReports() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceReportsRead,
+
+		Schema: map[string]*schema.Schema{
+			"workspace_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the workspace containing the reports.",
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				Description:  "Regular expression the report name must match.",
+			},
+			"dataset_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return reports bound to this dataset.",
+			},
+			"reports": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Reports matching the filters, ordered by name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the report.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the report.",
+						},
+						"dataset_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the dataset the report is bound to.",
+						},
+						"report_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the report.",
+						},
+						"web_url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Web URL of the report.",
+						},
+						"embed_url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Embed URL of the report.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceReportsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+	datasetID := d.Get("dataset_id").(string)
+
+	nameRegex, err := regexp.Compile(d.Get("name_regex").(string))
+	if err != nil {
+		return err
+	}
+
+	reports, err := client.GetReportsInGroupWithPagination(groupID, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get reports in workspace %s: %w", groupID, err)
+	}
+	sort.SliceStable(reports.Value, func(i, j int) bool {
+		return reports.Value[i].Name < reports.Value[j].Name
+	})
+
+	reportList := make([]interface{}, 0, len(reports.Value))
+	for _, report := range reports.Value {
+		if !nameRegex.MatchString(report.Name) {
+			continue
+		}
+		if datasetID != "" && !strings.EqualFold(datasetID, report.DatasetID) {
+			continue
+		}
+
+		reportList = append(reportList, map[string]interface{}{
+			"id":          report.ID,
+			"name":        report.Name,
+			"dataset_id":  report.DatasetID,
+			"report_type": report.ReportType,
+			"web_url":     report.WebURL,
+			"embed_url":   report.EmbedURL,
+		})
+	}
+
+	d.SetId(groupID)
+	d.Set("reports", reportList)
+
+	return nil
+}
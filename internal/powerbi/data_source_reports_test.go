@@ -0,0 +1,88 @@
+package powerbi
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDataSourceReports_basic(t *testing.T) {
+	workspaceSuffix := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerbiWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "powerbi_workspace" "test" {
+					name = "Acceptance Test Workspace %s"
+				}
+
+				resource "powerbi_pbix" "sample1" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name = "Acceptance Test PBIX 1"
+					source = "./resource_pbix_test_sample1.pbix"
+					source_hash = "${filemd5("./resource_pbix_test_sample1.pbix")}"
+				}
+
+				resource "powerbi_pbix" "sample2" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name = "Acceptance Test PBIX 2"
+					source = "./resource_pbix_test_sample2.pbix"
+					source_hash = "${filemd5("./resource_pbix_test_sample2.pbix")}"
+				}
+
+				data "powerbi_reports" "all" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					depends_on = [powerbi_pbix.sample1, powerbi_pbix.sample2]
+				}
+
+				data "powerbi_reports" "by_name" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name_regex = "1$"
+					depends_on = [powerbi_pbix.sample1, powerbi_pbix.sample2]
+				}
+
+				data "powerbi_reports" "by_dataset" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					dataset_id = "${powerbi_pbix.sample2.dataset_id}"
+				}
+
+				data "powerbi_datasets" "all" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					depends_on = [powerbi_pbix.sample1, powerbi_pbix.sample2]
+				}
+
+				data "powerbi_datasets" "by_name" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name_regex = "2$"
+					depends_on = [powerbi_pbix.sample1, powerbi_pbix.sample2]
+				}
+
+				data "powerbi_report_pages" "test" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					report_id = "${powerbi_pbix.sample1.report_id}"
+				}
+				`, workspaceSuffix),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerbi_reports.all", "reports.#", "2"),
+					resource.TestCheckResourceAttr("data.powerbi_reports.all", "reports.0.name", "Acceptance Test PBIX 1"),
+					resource.TestCheckResourceAttr("data.powerbi_reports.by_name", "reports.#", "1"),
+					resource.TestCheckResourceAttrPair("data.powerbi_reports.by_name", "reports.0.id", "powerbi_pbix.sample1", "report_id"),
+					resource.TestCheckResourceAttr("data.powerbi_reports.by_dataset", "reports.#", "1"),
+					resource.TestCheckResourceAttrPair("data.powerbi_reports.by_dataset", "reports.0.id", "powerbi_pbix.sample2", "report_id"),
+					resource.TestCheckResourceAttr("data.powerbi_datasets.all", "datasets.#", "2"),
+					resource.TestCheckResourceAttrSet("data.powerbi_datasets.all", "datasets.0.configured_by"),
+					resource.TestCheckResourceAttr("data.powerbi_datasets.by_name", "datasets.#", "1"),
+					resource.TestCheckResourceAttrPair("data.powerbi_datasets.by_name", "datasets.0.id", "powerbi_pbix.sample2", "dataset_id"),
+					resource.TestCheckResourceAttrSet("data.powerbi_report_pages.test", "pages.0.name"),
+					resource.TestCheckResourceAttr("data.powerbi_report_pages.test", "pages.0.order", "0"),
+				),
+			},
+		},
+	})
+}
@@ -10,6 +10,8 @@ import (
 // DataSourceTemplateApp returns information about Power BI template apps
 func DataSourceTemplateApp() *schema.Resource {
 	return &schema.Resource{
+		Description: "returns Power BI template apps.",
+
 		Read: dataSourceTemplateAppRead,
 
 		Schema: map[string]*schema.Schema{
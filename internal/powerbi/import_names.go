@@ -0,0 +1,189 @@
+package powerbi
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/codecutout/terraform-provider-powerbi/internal/powerbiapi"
+)
+
+var importGUIDRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// importNamedItem represents an object that can be found by its name when importing
+type importNamedItem struct {
+	ID   string
+	Name string
+}
+
+// resolveImportName returns the ID of the item identified by nameOrID within an import ID.
+// Values that are already IDs are returned without listing the items, otherwise exactly one
+// item must have the name
+func resolveImportName(kind string, container string, nameOrID string, listItems func() ([]importNamedItem, error)) (string, error) {
+	if importGUIDRegexp.MatchString(nameOrID) {
+		return nameOrID, nil
+	}
+
+	items, err := listItems()
+	if err != nil {
+		return "", fmt.Errorf("failed to find %s %q: %w", kind, nameOrID, err)
+	}
+
+	matchingIDs := []string{}
+	for _, item := range items {
+		if item.Name == nameOrID {
+			matchingIDs = append(matchingIDs, item.ID)
+		}
+	}
+
+	if len(matchingIDs) == 0 {
+		return "", fmt.Errorf("%s %q not found in %s", kind, nameOrID, container)
+	}
+	if len(matchingIDs) > 1 {
+		return "", fmt.Errorf("%s name %q is ambiguous in %s, it matches %d items with IDs %s. Import using the ID instead", kind, nameOrID, container, len(matchingIDs), strings.Join(matchingIDs, ", "))
+	}
+	return matchingIDs[0], nil
+}
+
+func resolveImportWorkspaceID(client *powerbiapi.Client, nameOrID string) (string, error) {
+	return resolveImportName("workspace", "the accessible workspaces", nameOrID, func() ([]importNamedItem, error) {
+		groups, err := client.GetGroups(fmt.Sprintf("name eq '%s'", strings.ReplaceAll(nameOrID, "'", "''")), -1, 0)
+		if err != nil {
+			return nil, err
+		}
+		items := []importNamedItem{}
+		for _, group := range groups.Value {
+			items = append(items, importNamedItem{ID: group.ID, Name: group.Name})
+		}
+		return items, nil
+	})
+}
+
+func resolveImportDatasetID(client *powerbiapi.Client, groupID string, nameOrID string) (string, error) {
+	return resolveImportName("dataset", "workspace "+groupID, nameOrID, func() ([]importNamedItem, error) {
+		datasets, err := client.GetDatasetsInGroup(groupID)
+		if err != nil {
+			return nil, err
+		}
+		items := []importNamedItem{}
+		for _, dataset := range datasets.Value {
+			items = append(items, importNamedItem{ID: dataset.ID, Name: dataset.Name})
+		}
+		return items, nil
+	})
+}
+
+// resolveImportReportID finds a report by name, only considering reports of reportType if it is set
+func resolveImportReportID(client *powerbiapi.Client, groupID string, nameOrID string, reportType string) (string, error) {
+	return resolveImportName("report", "workspace "+groupID, nameOrID, func() ([]importNamedItem, error) {
+		reports, err := client.GetReportsInGroup(groupID)
+		if err != nil {
+			return nil, err
+		}
+		items := []importNamedItem{}
+		for _, report := range reports.Value {
+			if reportType == "" || report.ReportType == reportType {
+				items = append(items, importNamedItem{ID: report.ID, Name: report.Name})
+			}
+		}
+		return items, nil
+	})
+}
+
+func resolveImportDashboardID(client *powerbiapi.Client, groupID string, nameOrID string) (string, error) {
+	return resolveImportName("dashboard", "workspace "+groupID, nameOrID, func() ([]importNamedItem, error) {
+		dashboards, err := client.GetDashboards(groupID)
+		if err != nil {
+			return nil, err
+		}
+		items := []importNamedItem{}
+		for _, dashboard := range dashboards.Value {
+			items = append(items, importNamedItem{ID: dashboard.ID, Name: dashboard.DisplayName})
+		}
+		return items, nil
+	})
+}
+
+func resolveImportDashboardTileID(client *powerbiapi.Client, groupID string, dashboardID string, titleOrID string) (string, error) {
+	return resolveImportName("tile", "dashboard "+dashboardID, titleOrID, func() ([]importNamedItem, error) {
+		tiles, err := client.GetTiles(groupID, dashboardID)
+		if err != nil {
+			return nil, err
+		}
+		items := []importNamedItem{}
+		for _, tile := range tiles.Value {
+			items = append(items, importNamedItem{ID: tile.ID, Name: tile.Title})
+		}
+		return items, nil
+	})
+}
+
+func resolveImportDataflowID(client *powerbiapi.Client, groupID string, nameOrID string) (string, error) {
+	return resolveImportName("dataflow", "workspace "+groupID, nameOrID, func() ([]importNamedItem, error) {
+		dataflows, err := client.GetDataflows(groupID)
+		if err != nil {
+			return nil, err
+		}
+		items := []importNamedItem{}
+		for _, dataflow := range dataflows.Value {
+			items = append(items, importNamedItem{ID: dataflow.ObjectID, Name: dataflow.Name})
+		}
+		return items, nil
+	})
+}
+
+func resolveImportGatewayID(client *powerbiapi.Client, nameOrID string) (string, error) {
+	return resolveImportName("gateway", "the accessible gateways", nameOrID, func() ([]importNamedItem, error) {
+		gateways, err := client.GetGateways()
+		if err != nil {
+			return nil, err
+		}
+		items := []importNamedItem{}
+		for _, gateway := range gateways.Value {
+			items = append(items, importNamedItem{ID: gateway.ID, Name: gateway.Name})
+		}
+		return items, nil
+	})
+}
+
+func resolveImportGatewayDatasourceID(client *powerbiapi.Client, gatewayID string, nameOrID string) (string, error) {
+	return resolveImportName("datasource", "gateway "+gatewayID, nameOrID, func() ([]importNamedItem, error) {
+		datasources, err := client.GetDatasources(gatewayID)
+		if err != nil {
+			return nil, err
+		}
+		items := []importNamedItem{}
+		for _, datasource := range datasources.Value {
+			items = append(items, importNamedItem{ID: datasource.ID, Name: datasource.DatasourceName})
+		}
+		return items, nil
+	})
+}
+
+func resolveImportPipelineID(client *powerbiapi.Client, nameOrID string) (string, error) {
+	return resolveImportName("deployment pipeline", "the accessible deployment pipelines", nameOrID, func() ([]importNamedItem, error) {
+		pipelines, err := client.GetPipelines()
+		if err != nil {
+			return nil, err
+		}
+		items := []importNamedItem{}
+		for _, pipeline := range pipelines.Value {
+			items = append(items, importNamedItem{ID: pipeline.ID, Name: pipeline.DisplayName})
+		}
+		return items, nil
+	})
+}
+
+func resolveImportPBIXImportID(client *powerbiapi.Client, groupID string, nameOrID string) (string, error) {
+	return resolveImportName("PBIX import", "workspace "+groupID, nameOrID, func() ([]importNamedItem, error) {
+		imports, err := client.GetImportsInGroup(groupID)
+		if err != nil {
+			return nil, err
+		}
+		items := []importNamedItem{}
+		for _, pbixImport := range imports.Value {
+			items = append(items, importNamedItem{ID: pbixImport.ID, Name: pbixImport.Name})
+		}
+		return items, nil
+	})
+}
@@ -0,0 +1,59 @@
+package powerbi
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestResolveImportName(t *testing.T) {
+	items := []importNamedItem{
+		{ID: "0e8ab1d2-1a2b-4c3d-8e9f-0a1b2c3d4e5f", Name: "Sales"},
+		{ID: "1f9bc2e3-2b3c-4d4e-9f0a-1b2c3d4e5f6a", Name: "Finance"},
+		{ID: "2a0cd3f4-3c4d-4e5f-8a1b-2c3d4e5f6a7b", Name: "Finance"},
+		{ID: "3b1de4a5-4d5e-4f6a-9b2c-3d4e5f6a7b8c", Name: "sales"},
+	}
+	listCalls := 0
+	listItems := func() ([]importNamedItem, error) {
+		listCalls++
+		return items, nil
+	}
+
+	tests := []struct {
+		nameOrID      string
+		expectedID    string
+		expectedError string
+	}{
+		{nameOrID: "Sales", expectedID: "0e8ab1d2-1a2b-4c3d-8e9f-0a1b2c3d4e5f"},
+		{nameOrID: "sales", expectedID: "3b1de4a5-4d5e-4f6a-9b2c-3d4e5f6a7b8c"},
+		{nameOrID: "Finance", expectedError: `dataset name "Finance" is ambiguous in workspace ws, it matches 2 items with IDs 1f9bc2e3-2b3c-4d4e-9f0a-1b2c3d4e5f6a, 2a0cd3f4-3c4d-4e5f-8a1b-2c3d4e5f6a7b`},
+		{nameOrID: "Marketing", expectedError: `dataset "Marketing" not found in workspace ws`},
+	}
+
+	for _, test := range tests {
+		id, err := resolveImportName("dataset", "workspace ws", test.nameOrID, listItems)
+		if test.expectedError != "" {
+			if err == nil || !strings.Contains(err.Error(), test.expectedError) {
+				t.Errorf("expected error %q for %s, got %v", test.expectedError, test.nameOrID, err)
+			}
+		} else if err != nil || id != test.expectedID {
+			t.Errorf("expected ID %s for %s, got %s with error %v", test.expectedID, test.nameOrID, id, err)
+		}
+	}
+
+	// IDs are used without listing, so importing by ID works as it did before names were supported
+	listCalls = 0
+	id, err := resolveImportName("dataset", "workspace ws", "9F8E7D6C-5B4A-4392-8170-6F5E4D3C2B1A", listItems)
+	if err != nil || id != "9F8E7D6C-5B4A-4392-8170-6F5E4D3C2B1A" || listCalls != 0 {
+		t.Errorf("expected ID to be returned without listing, got %s with error %v after %d calls", id, err, listCalls)
+	}
+}
+
+func TestResolveImportName_listError(t *testing.T) {
+	_, err := resolveImportName("dataset", "workspace ws", "Sales", func() ([]importNamedItem, error) {
+		return nil, errors.New("forbidden")
+	})
+	if err == nil || !strings.Contains(err.Error(), "forbidden") {
+		t.Errorf("expected list error to be returned, got %v", err)
+	}
+}
@@ -99,9 +99,18 @@ func Provider() *schema.Provider {
 		ResourcesMap: map[string]*schema.Resource{
 			"powerbi_workspace":                ResourceWorkspace(),
 			"powerbi_pbix":                     ResourcePBIX(),
+			"powerbi_pbip":                     ResourcePBIP(),
+			"powerbi_report":                   ResourceReport(),
+			"powerbi_report_export":            ResourceReportExport(),
+			"powerbi_paginated_report":         ResourcePaginatedReport(),
 			"powerbi_refresh_schedule":         ResourceRefreshSchedule(),
 			"powerbi_workspace_access":         ResourceGroupUsers(),
 			"powerbi_dataset":                  ResourceDataset(),
+			"powerbi_dataset_parameters":       ResourceDatasetParameters(),
+			"powerbi_dataset_datasources":      ResourceDatasetDatasources(),
+			"powerbi_dataset_takeover":         ResourceDatasetTakeover(),
+			"powerbi_dataset_gateway_binding":  ResourceDatasetGatewayBinding(),
+			"powerbi_dataset_datasource_credentials": ResourceDatasetDatasourceCredentials(),
 			"powerbi_dashboard":                ResourceDashboard(),
 			"powerbi_dashboard_tile":           ResourceDashboardTile(),
 			"powerbi_gateway_datasource":       ResourceGatewayDatasource(),
@@ -124,6 +133,12 @@ func Provider() *schema.Provider {
 			"powerbi_app_report":      DataSourceAppReport(),
 			"powerbi_embed_token":     DataSourceEmbedToken(),
 			"powerbi_template_app":    DataSourceTemplateApp(),
+			"powerbi_dataset_discover_gateways": DataSourceDatasetDiscoverGateways(),
+			"powerbi_reports":         DataSourceReports(),
+			"powerbi_datasets":        DataSourceDatasets(),
+			"powerbi_report_pages":    DataSourceReportPages(),
+			"powerbi_dataset":         DataSourceDataset(),
+			"powerbi_pbix_metadata":   DataSourcePBIXMetadata(),
 		},
 
 		ConfigureFunc: providerConfigure,
@@ -0,0 +1,123 @@
+package powerbi
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/codecutout/terraform-provider-powerbi/internal/powerbiapi"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// Power BI limits how often a schedule can refresh in a day depending on the capacity of the workspace
+const (
+	refreshScheduleSharedCapacityLimit  = 8
+	refreshSchedulePremiumCapacityLimit = 48
+)
+
+var refreshScheduleDays = []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
+
+var refreshScheduleTimeRegexp = regexp.MustCompile("^(0[0-9]|1[0-9]|2[0-3]):(00|30)$")
+
+// validateRefreshScheduleDay validates a day of the week a schedule refreshes on
+func validateRefreshScheduleDay(val interface{}, key string) (warns []string, errs []error) {
+	day := val.(string)
+	for _, validDay := range refreshScheduleDays {
+		if day == validDay {
+			return warns, errs
+		}
+	}
+	errs = append(errs, fmt.Errorf("Expected argument '%s' to be either 'Monday', 'Tuesday', 'Wednesday', 'Thursday', 'Friday', 'Saturday' or 'Sunday'. Found '%v'", key, day))
+	return warns, errs
+}
+
+// validateRefreshScheduleTime validates a time of day a schedule refreshes at
+func validateRefreshScheduleTime(val interface{}, key string) (warns []string, errs []error) {
+	time := val.(string)
+	if !refreshScheduleTimeRegexp.MatchString(time) {
+		errs = append(errs, fmt.Errorf("Expected argument '%s' to be in the format 'HH:00' or 'HH:30'. Hours must be two digits and must be on the hour or half hour. Found time '%v'", key, time))
+	}
+	return warns, errs
+}
+
+// validateRefreshScheduleTimeZone validates a time zone is either a Windows time zone ID or an IANA time zone that can be converted to one
+func validateRefreshScheduleTimeZone(val interface{}, key string) (warns []string, errs []error) {
+	timeZone := val.(string)
+	if _, ok := refreshScheduleTimeZones[normalizeRefreshScheduleTimeZone(timeZone)]; !ok {
+		errs = append(errs, fmt.Errorf("Expected argument '%s' to be a Windows time zone ID, such as 'UTC' or 'Pacific Standard Time', or an IANA time zone, such as 'America/Los_Angeles'. Found '%v'", key, timeZone))
+	}
+	return warns, errs
+}
+
+// normalizeRefreshScheduleTimeZone converts IANA time zones to the Windows time zone ID used by Power BI
+func normalizeRefreshScheduleTimeZone(timeZone string) string {
+	if _, ok := refreshScheduleTimeZones[timeZone]; ok {
+		return timeZone
+	}
+	if windowsTimeZone, ok := refreshScheduleTimeZoneAliases[timeZone]; ok {
+		return windowsTimeZone
+	}
+	for windowsTimeZone, ianaTimeZone := range refreshScheduleTimeZones {
+		if ianaTimeZone == timeZone {
+			return windowsTimeZone
+		}
+	}
+	return timeZone
+}
+
+// refreshScheduleTimeZoneStateFunc stores time zones as Windows time zone IDs so IANA time zones in config match the time zone read from Power BI
+func refreshScheduleTimeZoneStateFunc(val interface{}) string {
+	return normalizeRefreshScheduleTimeZone(val.(string))
+}
+
+// validateRefreshScheduleTimes checks the times a schedule refreshes at are unique and within the daily refresh limit
+func validateRefreshScheduleTimes(times []string, maxRefreshesPerDay int) error {
+	seen := make(map[string]bool)
+	for _, time := range times {
+		if seen[time] {
+			return fmt.Errorf("Expected argument 'times' to contain unique times. Found '%s' more than once", time)
+		}
+		seen[time] = true
+	}
+
+	if maxRefreshesPerDay > 0 && len(times) > maxRefreshesPerDay {
+		return fmt.Errorf("Expected argument 'times' to contain at most %d times as the workspace allows %d refreshes a day. Found %d times", maxRefreshesPerDay, maxRefreshesPerDay, len(times))
+	}
+	return nil
+}
+
+// refreshScheduleMaxRefreshesPerDay returns how many times a day a dataset or dataflow in the workspace can be refreshed, or 0 if it cannot be determined
+func refreshScheduleMaxRefreshesPerDay(client *powerbiapi.Client, groupID string) (int, error) {
+	group, err := client.GetGroup(groupID)
+	if err != nil {
+		return 0, err
+	}
+	if group == nil {
+		return 0, nil
+	}
+	if group.IsOnDedicatedCapacity || group.CapacityID != "" {
+		return refreshSchedulePremiumCapacityLimit, nil
+	}
+	return refreshScheduleSharedCapacityLimit, nil
+}
+
+// customizeRefreshScheduleDiff validates the times of powerbi_refresh_schedule and powerbi_dataflow_refresh_schedule
+// during plan, looking up the capacity of the workspace to determine how many refreshes are allowed each day
+func customizeRefreshScheduleDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("times") {
+		return nil
+	}
+	times := convertToStringSlice(d.Get("times").([]interface{}))
+
+	maxRefreshesPerDay := 0
+	client, ok := meta.(*powerbiapi.Client)
+	if ok && client != nil && d.NewValueKnown("workspace_id") && len(times) > refreshScheduleSharedCapacityLimit {
+		groupID := d.Get("workspace_id").(string)
+		var err error
+		maxRefreshesPerDay, err = refreshScheduleMaxRefreshesPerDay(client, groupID)
+		if err != nil {
+			return fmt.Errorf("failed to look up the capacity of workspace %s: %w", groupID, err)
+		}
+	}
+
+	return validateRefreshScheduleTimes(times, maxRefreshesPerDay)
+}
@@ -0,0 +1,105 @@
+package powerbi
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateRefreshScheduleDay(t *testing.T) {
+	for _, day := range []string{"Monday", "Sunday"} {
+		if _, errs := validateRefreshScheduleDay(day, "days.0"); len(errs) != 0 {
+			t.Errorf("expected %s to be valid, got %v", day, errs)
+		}
+	}
+	for _, day := range []string{"monday", "Mon", "Badday", ""} {
+		if _, errs := validateRefreshScheduleDay(day, "days.0"); len(errs) != 1 || !strings.Contains(errs[0].Error(), "days.0") {
+			t.Errorf("expected %q to be invalid, got %v", day, errs)
+		}
+	}
+}
+
+func TestValidateRefreshScheduleTime(t *testing.T) {
+	for _, time := range []string{"00:00", "09:30", "23:30"} {
+		if _, errs := validateRefreshScheduleTime(time, "times.0"); len(errs) != 0 {
+			t.Errorf("expected %s to be valid, got %v", time, errs)
+		}
+	}
+	for _, time := range []string{"9:30", "09:45", "24:00", "09:00:00", ""} {
+		if _, errs := validateRefreshScheduleTime(time, "times.0"); len(errs) != 1 {
+			t.Errorf("expected %q to be invalid, got %v", time, errs)
+		}
+	}
+}
+
+func TestValidateRefreshScheduleTimeZone(t *testing.T) {
+	tests := map[string]string{
+		"UTC":                   "UTC",
+		"Pacific Standard Time": "Pacific Standard Time",
+		"America/Los_Angeles":   "Pacific Standard Time",
+		"Europe/London":         "GMT Standard Time",
+		"Europe/Dublin":         "GMT Standard Time",
+		"Asia/Calcutta":         "India Standard Time",
+		"Etc/UTC":               "UTC",
+	}
+	for timeZone, expected := range tests {
+		if _, errs := validateRefreshScheduleTimeZone(timeZone, "local_time_zone_id"); len(errs) != 0 {
+			t.Errorf("expected %s to be valid, got %v", timeZone, errs)
+		}
+		if actual := refreshScheduleTimeZoneStateFunc(timeZone); actual != expected {
+			t.Errorf("expected %s to be converted to %s, got %s", timeZone, expected, actual)
+		}
+	}
+
+	for _, timeZone := range []string{"pacific standard time", "Mars/Olympus_Mons", "PST"} {
+		if _, errs := validateRefreshScheduleTimeZone(timeZone, "local_time_zone_id"); len(errs) != 1 {
+			t.Errorf("expected %q to be invalid, got %v", timeZone, errs)
+		}
+	}
+}
+
+func TestRefreshScheduleTimeZones(t *testing.T) {
+	// every IANA time zone must convert to a single Windows time zone
+	windowsTimeZones := make(map[string]string)
+	for windowsTimeZone, ianaTimeZone := range refreshScheduleTimeZones {
+		if existing, ok := windowsTimeZones[ianaTimeZone]; ok {
+			t.Errorf("IANA time zone %s maps to both %s and %s", ianaTimeZone, existing, windowsTimeZone)
+		}
+		windowsTimeZones[ianaTimeZone] = windowsTimeZone
+	}
+	for ianaTimeZone, windowsTimeZone := range refreshScheduleTimeZoneAliases {
+		if _, ok := refreshScheduleTimeZones[windowsTimeZone]; !ok {
+			t.Errorf("alias %s maps to unknown Windows time zone %s", ianaTimeZone, windowsTimeZone)
+		}
+		if _, ok := windowsTimeZones[ianaTimeZone]; ok {
+			t.Errorf("alias %s is already mapped by refreshScheduleTimeZones", ianaTimeZone)
+		}
+	}
+}
+
+func TestValidateRefreshScheduleTimes(t *testing.T) {
+	eightTimes := []string{"00:00", "03:00", "06:00", "09:00", "12:00", "15:00", "18:00", "21:00"}
+	nineTimes := append(append([]string{}, eightTimes...), "22:30")
+
+	tests := []struct {
+		times              []string
+		maxRefreshesPerDay int
+		expectedError      string
+	}{
+		{times: []string{}, maxRefreshesPerDay: refreshScheduleSharedCapacityLimit},
+		{times: eightTimes, maxRefreshesPerDay: refreshScheduleSharedCapacityLimit},
+		{times: nineTimes, maxRefreshesPerDay: refreshSchedulePremiumCapacityLimit},
+		{times: nineTimes, maxRefreshesPerDay: 0},
+		{times: nineTimes, maxRefreshesPerDay: refreshScheduleSharedCapacityLimit, expectedError: "at most 8 times"},
+		{times: []string{"09:00", "17:30", "09:00"}, maxRefreshesPerDay: refreshScheduleSharedCapacityLimit, expectedError: "Found '09:00' more than once"},
+	}
+
+	for _, test := range tests {
+		err := validateRefreshScheduleTimes(test.times, test.maxRefreshesPerDay)
+		if test.expectedError == "" && err != nil {
+			t.Errorf("expected %v to be valid with limit %d, got %v", test.times, test.maxRefreshesPerDay, err)
+		}
+		if test.expectedError != "" && (err == nil || !strings.Contains(err.Error(), test.expectedError)) {
+			t.Errorf("expected error %q for %v with limit %d, got %v", test.expectedError, test.times, test.maxRefreshesPerDay, err)
+		}
+	}
+}
@@ -17,12 +17,21 @@ func ResourceDashboard() *schema.Resource {
 		Delete: deleteDashboard,
 		Importer: &schema.ResourceImporter{
 			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				client := meta.(*powerbiapi.Client)
 				parts := strings.Split(d.Id(), "/")
 				if len(parts) != 2 {
-					return nil, fmt.Errorf("invalid dashboard import id format, expected 'workspace_id/dashboard_id'")
+					return nil, fmt.Errorf("invalid dashboard import id format, expected 'workspace_id/dashboard_id' or 'workspace_name/dashboard_name'")
 				}
-				d.Set("workspace_id", parts[0])
-				d.SetId(parts[1])
+				groupID, err := resolveImportWorkspaceID(client, parts[0])
+				if err != nil {
+					return nil, err
+				}
+				dashboardID, err := resolveImportDashboardID(client, groupID, parts[1])
+				if err != nil {
+					return nil, err
+				}
+				d.Set("workspace_id", groupID)
+				d.SetId(dashboardID)
 				return []*schema.ResourceData{d}, nil
 			},
 		},
@@ -50,6 +50,12 @@ func TestAccDashboard_importBasic(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateIdFunc: testAccDashboardImportStateIdFunc("powerbi_dashboard.test"),
 			},
+			{
+				ResourceName:      "powerbi_dashboard.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     fmt.Sprintf("%s/%s", workspaceName, dashboardName),
+			},
 		},
 	})
 }
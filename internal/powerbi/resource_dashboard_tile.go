@@ -16,13 +16,26 @@ func ResourceDashboardTile() *schema.Resource {
 		Delete: deleteDashboardTile,
 		Importer: &schema.ResourceImporter{
 			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				client := meta.(*powerbiapi.Client)
 				parts := strings.Split(d.Id(), "/")
 				if len(parts) != 3 {
-					return nil, fmt.Errorf("invalid tile import id format, expected 'workspace_id/dashboard_id/tile_id'")
+					return nil, fmt.Errorf("invalid tile import id format, expected 'workspace_id/dashboard_id/tile_id' or 'workspace_name/dashboard_name/tile_title'")
 				}
-				d.Set("workspace_id", parts[0])
-				d.Set("dashboard_id", parts[1])
-				d.SetId(parts[2])
+				groupID, err := resolveImportWorkspaceID(client, parts[0])
+				if err != nil {
+					return nil, err
+				}
+				dashboardID, err := resolveImportDashboardID(client, groupID, parts[1])
+				if err != nil {
+					return nil, err
+				}
+				tileID, err := resolveImportDashboardTileID(client, groupID, dashboardID, parts[2])
+				if err != nil {
+					return nil, err
+				}
+				d.Set("workspace_id", groupID)
+				d.Set("dashboard_id", dashboardID)
+				d.SetId(tileID)
 				return []*schema.ResourceData{d}, nil
 			},
 		},
@@ -75,7 +88,7 @@ func ResourceDashboardTile() *schema.Resource {
 				Optional:    true,
 				ForceNew:    true,
 				Default:     "Tail",
-				Description: "Action to take if tile position conflicts. Options: 'Tail' or 'Abort'.",
+				Description: "Action to take if tile position conflicts. Options: `Tail` or `Abort`.",
 			},
 			"title": {
 				Type:        schema.TypeString,
@@ -12,18 +12,29 @@ import (
 // ResourceDataflow represents a Power BI dataflow
 func ResourceDataflow() *schema.Resource {
 	return &schema.Resource{
+		Description: "represents a dataflow within a Power BI workspace.",
+
 		Create: createDataflow,
 		Read:   readDataflow,
 		Update: updateDataflow,
 		Delete: deleteDataflow,
 		Importer: &schema.ResourceImporter{
 			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				client := meta.(*powerbiapi.Client)
 				parts := strings.Split(d.Id(), "/")
 				if len(parts) != 2 {
-					return nil, fmt.Errorf("invalid dataflow import id format, expected 'workspace_id/dataflow_id'")
+					return nil, fmt.Errorf("invalid dataflow import id format, expected 'workspace_id/dataflow_id' or 'workspace_name/dataflow_name'")
+				}
+				groupID, err := resolveImportWorkspaceID(client, parts[0])
+				if err != nil {
+					return nil, err
+				}
+				dataflowID, err := resolveImportDataflowID(client, groupID, parts[1])
+				if err != nil {
+					return nil, err
 				}
-				d.Set("workspace_id", parts[0])
-				d.SetId(parts[1])
+				d.Set("workspace_id", groupID)
+				d.SetId(dataflowID)
 				return []*schema.ResourceData{d}, nil
 			},
 		},
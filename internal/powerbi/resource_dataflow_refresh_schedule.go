@@ -2,7 +2,6 @@ package powerbi
 
 import (
 	"fmt"
-	"regexp"
 	"strings"
 
 	"github.com/codecutout/terraform-provider-powerbi/internal/powerbiapi"
@@ -13,22 +12,34 @@ import (
 // ResourceDataflowRefreshSchedule represents a Power BI dataflow refresh schedule
 func ResourceDataflowRefreshSchedule() *schema.Resource {
 	return &schema.Resource{
+		Description: "represents the refresh schedule of a dataflow.",
+
 		Create: createDataflowRefreshSchedule,
 		Read:   readDataflowRefreshSchedule,
 		Update: updateDataflowRefreshSchedule,
 		Delete: deleteDataflowRefreshSchedule,
 		Importer: &schema.ResourceImporter{
 			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				client := meta.(*powerbiapi.Client)
 				parts := strings.Split(d.Id(), "/")
 				if len(parts) != 2 {
-					return nil, fmt.Errorf("invalid dataflow refresh schedule import id format, expected 'workspace_id/dataflow_id'")
+					return nil, fmt.Errorf("invalid dataflow refresh schedule import id format, expected 'workspace_id/dataflow_id' or 'workspace_name/dataflow_name'")
+				}
+				groupID, err := resolveImportWorkspaceID(client, parts[0])
+				if err != nil {
+					return nil, err
+				}
+				dataflowID, err := resolveImportDataflowID(client, groupID, parts[1])
+				if err != nil {
+					return nil, err
 				}
-				d.Set("workspace_id", parts[0])
-				d.Set("dataflow_id", parts[1])
-				d.SetId(fmt.Sprintf("%s/%s", parts[0], parts[1]))
+				d.Set("workspace_id", groupID)
+				d.Set("dataflow_id", dataflowID)
+				d.SetId(fmt.Sprintf("%s/%s", groupID, dataflowID))
 				return []*schema.ResourceData{d}, nil
 			},
 		},
+		CustomizeDiff: customizeRefreshScheduleDiff,
 
 		Schema: map[string]*schema.Schema{
 			"workspace_id": {
@@ -53,28 +64,25 @@ func ResourceDataflowRefreshSchedule() *schema.Resource {
 				Optional:    true,
 				Description: "Days of the week when the dataflow should be refreshed.",
 				Elem: &schema.Schema{
-					Type: schema.TypeString,
-					ValidateFunc: validation.StringInSlice([]string{
-						"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday",
-					}, false),
+					Type:         schema.TypeString,
+					ValidateFunc: validateRefreshScheduleDay,
 				},
 			},
 			"times": {
 				Type:        schema.TypeList,
 				Optional:    true,
-				Description: "Times of day when the dataflow should be refreshed (in HH:MM format).",
+				Description: "Times of day when the dataflow should be refreshed, in the format HH:00 or HH:30. Times must be unique, and at most 8 times can be set for workspaces on shared capacity or 48 for workspaces on Premium capacity.",
 				Elem: &schema.Schema{
-					Type: schema.TypeString,
-					ValidateFunc: validation.StringMatch(
-						regexp.MustCompile(`^([01]?[0-9]|2[0-3]):[0-5][0-9]$`),
-						"time must be in HH:MM format (24-hour)",
-					),
+					Type:         schema.TypeString,
+					ValidateFunc: validateRefreshScheduleTime,
 				},
 			},
 			"local_time_zone_id": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Time zone ID for the refresh schedule.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Windows time zone ID for the refresh schedule, such as `UTC` or `Pacific Standard Time`. IANA time zones such as `Europe/London` are converted to the equivalent Windows time zone.",
+				ValidateFunc: validateRefreshScheduleTimeZone,
+				StateFunc:    refreshScheduleTimeZoneStateFunc,
 			},
 			"notify_option": {
 				Type:        schema.TypeString,
@@ -213,7 +221,7 @@ func buildDataflowRefreshSchedule(d *schema.ResourceData) powerbiapi.DataflowRef
 	}
 	
 	if v, ok := d.GetOk("local_time_zone_id"); ok {
-		schedule.LocalTimeZoneID = v.(string)
+		schedule.LocalTimeZoneID = normalizeRefreshScheduleTimeZone(v.(string))
 	}
 	
 	if v, ok := d.GetOk("notify_option"); ok {
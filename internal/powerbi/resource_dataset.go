@@ -19,12 +19,21 @@ func ResourceDataset() *schema.Resource {
 		Delete: deleteDataset,
 		Importer: &schema.ResourceImporter{
 			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				client := meta.(*powerbiapi.Client)
 				idParts := strings.Split(d.Id(), "/")
 				if len(idParts) != 2 {
-					return nil, fmt.Errorf("invalid import ID, expected format: workspace_id/dataset_id")
+					return nil, fmt.Errorf("invalid import ID, expected format: workspace_id/dataset_id or workspace_name/dataset_name")
 				}
-				d.Set("workspace_id", idParts[0])
-				d.SetId(idParts[1])
+				groupID, err := resolveImportWorkspaceID(client, idParts[0])
+				if err != nil {
+					return nil, err
+				}
+				datasetID, err := resolveImportDatasetID(client, groupID, idParts[1])
+				if err != nil {
+					return nil, err
+				}
+				d.Set("workspace_id", groupID)
+				d.SetId(datasetID)
 				return []*schema.ResourceData{d}, nil
 			},
 		},
@@ -271,7 +280,7 @@ func readDataset(d *schema.ResourceData, meta interface{}) error {
 	groupID := d.Get("workspace_id").(string)
 
 	dataset, err := client.GetDatasetInGroup(groupID, d.Id())
-	if isHTTP404Error(err) {
+	if powerbiapi.IsHTTP404Error(err) {
 		d.SetId("")
 		return nil
 	}
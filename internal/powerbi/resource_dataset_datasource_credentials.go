@@ -0,0 +1,328 @@
+package powerbi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/codecutout/terraform-provider-powerbi/internal/powerbiapi"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// ResourceDatasetDatasourceCredentials represents the credentials of a cloud datasource used by a Power BI dataset
+func ResourceDatasetDatasourceCredentials() *schema.Resource {
+	return &schema.Resource{
+		Create: createDatasetDatasourceCredentials,
+		Read:   readDatasetDatasourceCredentials,
+		Update: updateDatasetDatasourceCredentials,
+		Delete: deleteDatasetDatasourceCredentials,
+		Importer: &schema.ResourceImporter{
+			State: importDatasetDatasourceCredentials,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"workspace_id": {
+				Type:        schema.TypeString,
+				Description: "Workspace ID in which the dataset exists.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"dataset_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the dataset using the datasource.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"datasource_type": {
+				Type:        schema.TypeString,
+				Description: "The type of datasource to set credentials for. For example Sql, SharePointList",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"server": {
+				Type:        schema.TypeString,
+				Description: "The server name used to locate the datasource, if applicable for the type of datasource. If `server`, `database` and `url` are all omitted the dataset must have only one datasource of `datasource_type`",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"database": {
+				Type:        schema.TypeString,
+				Description: "The database name used to locate the datasource, if applicable for the type of datasource",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Description: "The service URL used to locate the datasource, if applicable for the type of datasource",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"take_over": {
+				Type:        schema.TypeBool,
+				Description: "If true, the dataset will be taken over by the current user before setting credentials. Required when the dataset is owned by another user or service principal.",
+				Optional:    true,
+				Default:     false,
+			},
+			"credential_type": {
+				Type:        schema.TypeString,
+				Description: "Type of credentials used for authentication. Options: `Basic`, `Windows`, `OAuth2`, `Anonymous`, `Key`.",
+				Required:    true,
+				ForceNew:    true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"Basic", "Windows", "OAuth2", "Anonymous", "Key",
+				}, false),
+			},
+			"username": {
+				Type:        schema.TypeString,
+				Description: "Username for `Basic` and `Windows` credentials.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Description: "Password for `Basic` and `Windows` credentials. Only a hash of the password is stored in state.",
+				Optional:    true,
+				Sensitive:   true,
+				StateFunc:   hashSensitiveValue,
+			},
+			"key": {
+				Type:        schema.TypeString,
+				Description: "Key for `Key` credentials. Only a hash of the key is stored in state.",
+				Optional:    true,
+				Sensitive:   true,
+				StateFunc:   hashSensitiveValue,
+			},
+			"access_token": {
+				Type:        schema.TypeString,
+				Description: "Access token for `OAuth2` credentials. Only a hash of the access token is stored in state.",
+				Optional:    true,
+				Sensitive:   true,
+				StateFunc:   hashSensitiveValue,
+			},
+			"encrypted_connection": {
+				Type:        schema.TypeString,
+				Description: "Whether the connection to the datasource is encrypted. Options: `Encrypted`, `NotEncrypted`.",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "Encrypted",
+				ValidateFunc: validation.StringInSlice([]string{
+					"Encrypted", "NotEncrypted",
+				}, false),
+			},
+			"privacy_level": {
+				Type:        schema.TypeString,
+				Description: "Privacy level for the datasource. Options: `None`, `Public`, `Organizational`, `Private`.",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "None",
+				ValidateFunc: validation.StringInSlice([]string{
+					"None", "Public", "Organizational", "Private",
+				}, false),
+			},
+			"datasource_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the datasource.",
+				Computed:    true,
+			},
+			"gateway_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the gateway the datasource belongs to. For cloud datasources this is the ID of the cloud gateway.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func importDatasetDatasourceCredentials(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*powerbiapi.Client)
+
+	idParts := strings.Split(d.Id(), "/")
+	if len(idParts) != 3 {
+		return nil, fmt.Errorf("invalid import ID, expected format: workspace_id/dataset_id/datasource_id or workspace_name/dataset_name/datasource_id")
+	}
+	groupID, err := resolveImportWorkspaceID(client, idParts[0])
+	if err != nil {
+		return nil, err
+	}
+	datasetID, err := resolveImportDatasetID(client, groupID, idParts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	datasources, err := client.GetDatasourcesInGroup(groupID, datasetID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, datasource := range datasources.Value {
+		if strings.EqualFold(datasource.DatasourceID, idParts[2]) {
+			d.Set("workspace_id", groupID)
+			d.Set("dataset_id", datasetID)
+			d.Set("datasource_type", datasource.DatasourceType)
+			d.Set("server", nilToEmptyString(datasource.ConnectionDetails.Server))
+			d.Set("database", nilToEmptyString(datasource.ConnectionDetails.Database))
+			d.Set("url", nilToEmptyString(datasource.ConnectionDetails.URL))
+			d.Set("encrypted_connection", "Encrypted")
+			d.Set("privacy_level", "None")
+			d.SetId(fmt.Sprintf("%s/%s/%s", groupID, datasetID, datasource.DatasourceID))
+			return []*schema.ResourceData{d}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("datasource %s not found in dataset %s", idParts[2], idParts[1])
+}
+
+func createDatasetDatasourceCredentials(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+	datasetID := d.Get("dataset_id").(string)
+
+	err := takeOverDatasetIfRequired(d, meta)
+	if err != nil {
+		return err
+	}
+
+	datasources, err := client.GetDatasourcesInGroup(groupID, datasetID)
+	if err != nil {
+		return err
+	}
+
+	datasourceType := d.Get("datasource_type").(string)
+	server := d.Get("server").(string)
+	database := d.Get("database").(string)
+	url := d.Get("url").(string)
+	var datasource *powerbiapi.GetDatasourcesInGroupResponseItem
+	if server == "" && database == "" && url == "" {
+		datasource = findOnlyDatasourceInGroupOfType(datasources.Value, datasourceType)
+	} else {
+		datasource = findDatasourceInGroup(datasources.Value, datasourceType, server, database, url)
+	}
+	if datasource == nil {
+		return fmt.Errorf("unable to find a single %s datasource matching server, database and url in dataset %s", datasourceType, datasetID)
+	}
+	if datasource.GatewayID == "" || datasource.DatasourceID == "" {
+		return fmt.Errorf("%s datasource in dataset %s is not bound to a gateway or cloud connection", datasourceType, datasetID)
+	}
+
+	err = setDatasetDatasourceCredentials(d, meta, datasource.GatewayID, datasource.DatasourceID)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", groupID, datasetID, datasource.DatasourceID))
+
+	return readDatasetDatasourceCredentials(d, meta)
+}
+
+func readDatasetDatasourceCredentials(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+	datasetID := d.Get("dataset_id").(string)
+	idParts := strings.Split(d.Id(), "/")
+	datasourceID := idParts[len(idParts)-1]
+
+	datasources, err := client.GetDatasourcesInGroup(groupID, datasetID)
+	if powerbiapi.IsHTTP404Error(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// if the dataset no longer uses the datasource it has been rebound, so the credentials need to be set again
+	var datasource *powerbiapi.GetDatasourcesInGroupResponseItem
+	for i := range datasources.Value {
+		if strings.EqualFold(datasources.Value[i].DatasourceID, datasourceID) {
+			datasource = &datasources.Value[i]
+		}
+	}
+	if datasource == nil {
+		d.SetId("")
+		return nil
+	}
+
+	gatewayDatasource, err := client.GetDatasource(datasource.GatewayID, datasource.DatasourceID)
+	if powerbiapi.IsHTTP404Error(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	d.Set("datasource_id", datasource.DatasourceID)
+	d.Set("gateway_id", datasource.GatewayID)
+	d.Set("credential_type", gatewayDatasource.CredentialType)
+	return nil
+}
+
+func updateDatasetDatasourceCredentials(d *schema.ResourceData, meta interface{}) error {
+	// only secrets can be updated in place. Unchanged secrets are only known as a hash so
+	// any other credential change forces the credentials to be set again from config
+	if d.HasChange("password") || d.HasChange("key") || d.HasChange("access_token") {
+		err := takeOverDatasetIfRequired(d, meta)
+		if err != nil {
+			return err
+		}
+
+		err = setDatasetDatasourceCredentials(d, meta, d.Get("gateway_id").(string), d.Get("datasource_id").(string))
+		if err != nil {
+			return err
+		}
+	}
+
+	return readDatasetDatasourceCredentials(d, meta)
+}
+
+func deleteDatasetDatasourceCredentials(d *schema.ResourceData, meta interface{}) error {
+	// Credentials cannot be removed from a datasource, they remain at their last value
+	d.SetId("")
+	return nil
+}
+
+func setDatasetDatasourceCredentials(d *schema.ResourceData, meta interface{}, gatewayID string, datasourceID string) error {
+	client := meta.(*powerbiapi.Client)
+
+	credentialType := d.Get("credential_type").(string)
+	credentials, err := buildPlaintextCredentials(map[string]interface{}{
+		"username":     d.Get("username"),
+		"password":     d.Get("password"),
+		"key":          d.Get("key"),
+		"access_token": d.Get("access_token"),
+	}, credentialType)
+	if err != nil {
+		return err
+	}
+	if credentials == "" {
+		if credentialType != "Anonymous" {
+			return fmt.Errorf("%s credentials require username, password, key or access_token to be set", credentialType)
+		}
+		credentials = powerbiapi.BuildAnonymousCredentials()
+	}
+
+	// cloud datasources are not encrypted with a gateway key, the credentials are sent as is
+	return client.UpdateDatasource(gatewayID, datasourceID, powerbiapi.UpdateDatasourceRequest{
+		CredentialDetails: &powerbiapi.DatasourceCredentialDetails{
+			CredentialType:      credentialType,
+			Credentials:         credentials,
+			EncryptedConnection: d.Get("encrypted_connection").(string),
+			EncryptionAlgorithm: "None",
+			PrivacyLevel:        d.Get("privacy_level").(string),
+		},
+	})
+}
+
+// hashSensitiveValue is used as a StateFunc so secrets are only stored in state as a hash
+func hashSensitiveValue(value interface{}) string {
+	s, ok := value.(string)
+	if !ok || s == "" {
+		return ""
+	}
+	hash := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(hash[:])
+}
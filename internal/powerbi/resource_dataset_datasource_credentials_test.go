@@ -0,0 +1,92 @@
+package powerbi
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccDatasetDatasourceCredentials_basic(t *testing.T) {
+	workspaceSuffix := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerbiWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			// first step sets credentials on the only OData datasource
+			{
+				Config: testAccDatasetDatasourceCredentialsConfig(workspaceSuffix, "Public"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_dataset_datasource_credentials.test", "credential_type", "Anonymous"),
+					resource.TestCheckResourceAttr("powerbi_dataset_datasource_credentials.test", "privacy_level", "Public"),
+					resource.TestCheckResourceAttrSet("powerbi_dataset_datasource_credentials.test", "datasource_id"),
+					resource.TestCheckResourceAttrSet("powerbi_dataset_datasource_credentials.test", "gateway_id"),
+				),
+			},
+			// changing privacy level sets the credentials again
+			{
+				Config: testAccDatasetDatasourceCredentialsConfig(workspaceSuffix, "Organizational"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_dataset_datasource_credentials.test", "credential_type", "Anonymous"),
+					resource.TestCheckResourceAttr("powerbi_dataset_datasource_credentials.test", "privacy_level", "Organizational"),
+				),
+			},
+			// import locates the datasource by ID
+			{
+				ResourceName:            "powerbi_dataset_datasource_credentials.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"url", "privacy_level", "take_over"},
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources["powerbi_dataset_datasource_credentials.test"]
+					if !ok {
+						return "", fmt.Errorf("not found: powerbi_dataset_datasource_credentials.test")
+					}
+					return rs.Primary.ID, nil
+				},
+			},
+		},
+	})
+}
+
+func TestHashSensitiveValue(t *testing.T) {
+	if hashSensitiveValue("") != "" {
+		t.Errorf("expected empty value to stay empty")
+	}
+	if hashSensitiveValue(nil) != "" {
+		t.Errorf("expected nil value to be empty")
+	}
+
+	// echo -n "password" | sha256sum
+	expected := "5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8"
+	if actual := hashSensitiveValue("password"); actual != expected {
+		t.Errorf("expected %s, got %s", expected, actual)
+	}
+}
+
+func testAccDatasetDatasourceCredentialsConfig(workspaceSuffix string, privacyLevel string) string {
+	return fmt.Sprintf(`
+	resource "powerbi_workspace" "test" {
+		name = "Acceptance Test Workspace %s"
+	}
+
+	resource "powerbi_pbix" "test" {
+		workspace_id = "${powerbi_workspace.test.id}"
+		name = "Acceptance Test PBIX"
+		source = "./resource_pbix_test_sample1.pbix"
+		source_hash = "${filemd5("./resource_pbix_test_sample1.pbix")}"
+	}
+
+	resource "powerbi_dataset_datasource_credentials" "test" {
+		workspace_id = "${powerbi_workspace.test.id}"
+		dataset_id = "${powerbi_pbix.test.dataset_id}"
+		datasource_type = "OData"
+		credential_type = "Anonymous"
+		privacy_level = "%s"
+	}
+	`, workspaceSuffix, privacyLevel)
+}
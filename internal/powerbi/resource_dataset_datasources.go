@@ -0,0 +1,312 @@
+package powerbi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/codecutout/terraform-provider-powerbi/internal/powerbiapi"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// ResourceDatasetDatasources represents the datasources of an existing Power BI dataset
+func ResourceDatasetDatasources() *schema.Resource {
+	return &schema.Resource{
+		Create: createDatasetDatasources,
+		Read:   readDatasetDatasources,
+		Update: updateDatasetDatasources,
+		Delete: deleteDatasetDatasources,
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				client := meta.(*powerbiapi.Client)
+				idParts := strings.Split(d.Id(), "/")
+				if len(idParts) != 2 {
+					return nil, fmt.Errorf("invalid import ID, expected format: workspace_id/dataset_id or workspace_name/dataset_name")
+				}
+				groupID, err := resolveImportWorkspaceID(client, idParts[0])
+				if err != nil {
+					return nil, err
+				}
+				datasetID, err := resolveImportDatasetID(client, groupID, idParts[1])
+				if err != nil {
+					return nil, err
+				}
+				d.Set("workspace_id", groupID)
+				d.Set("dataset_id", datasetID)
+				d.SetId(fmt.Sprintf("%s/%s", groupID, datasetID))
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"workspace_id": {
+				Type:        schema.TypeString,
+				Description: "Workspace ID in which the dataset exists.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"dataset_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the dataset whose datasources will be managed. The dataset can be deployed by any means.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"take_over": {
+				Type:        schema.TypeBool,
+				Description: "If true, the dataset will be taken over by the current user before updating datasources. Required when the dataset is owned by another user or service principal.",
+				Optional:    true,
+				Default:     false,
+			},
+			"datasource": {
+				Type:        schema.TypeSet,
+				Description: "Datasources to be reconfigured on the dataset. Each datasource is located using the `type` and `original_*` fields and updated to the `server`, `database` and `url` values",
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:        schema.TypeString,
+							Description: "The type of datasource. For example web, sql",
+							Optional:    true,
+						},
+						"database": {
+							Type:        schema.TypeString,
+							Description: "The database name, if applicable for the type of datasource",
+							Optional:    true,
+						},
+						"server": {
+							Type:        schema.TypeString,
+							Description: "The server name, if applicable for the type of datasource",
+							Optional:    true,
+						},
+						"url": {
+							Type:        schema.TypeString,
+							Description: "The service URL, if applicable for the type of datasource",
+							Optional:    true,
+						},
+						"original_database": {
+							Type:        schema.TypeString,
+							Description: "The database name as configured in the dataset, if applicable for the type of datasource. This will be the value replaced with the value in the 'database' field",
+							Optional:    true,
+						},
+						"original_server": {
+							Type:        schema.TypeString,
+							Description: "The server name as configured in the dataset, if applicable for the type of datasource. This will be the value replaced with the value in the 'server' field",
+							Optional:    true,
+						},
+						"original_url": {
+							Type:        schema.TypeString,
+							Description: "The service URL as configured in the dataset, if applicable for the type of datasource. This will be the value replaced with the value in the 'url' field",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"all_datasources": {
+				Type:        schema.TypeList,
+				Description: "All datasources currently used by the dataset, including those not managed by this resource.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"datasource_id": {
+							Type:        schema.TypeString,
+							Description: "The ID of the datasource",
+							Computed:    true,
+						},
+						"gateway_id": {
+							Type:        schema.TypeString,
+							Description: "The ID of the gateway the datasource is bound to",
+							Computed:    true,
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Description: "The type of datasource",
+							Computed:    true,
+						},
+						"database": {
+							Type:        schema.TypeString,
+							Description: "The database name",
+							Computed:    true,
+						},
+						"server": {
+							Type:        schema.TypeString,
+							Description: "The server name",
+							Computed:    true,
+						},
+						"url": {
+							Type:        schema.TypeString,
+							Description: "The service URL",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func createDatasetDatasources(d *schema.ResourceData, meta interface{}) error {
+	groupID := d.Get("workspace_id").(string)
+	datasetID := d.Get("dataset_id").(string)
+
+	err := takeOverDatasetIfRequired(d, meta)
+	if err != nil {
+		return err
+	}
+
+	err = setDatasetDatasources(d, meta)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", groupID, datasetID))
+
+	return readDatasetDatasources(d, meta)
+}
+
+func readDatasetDatasources(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+	datasetID := d.Get("dataset_id").(string)
+
+	apiDatasources, err := client.GetDatasourcesInGroup(groupID, datasetID)
+	if powerbiapi.IsHTTP404Error(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	allDatasources := make([]interface{}, 0, len(apiDatasources.Value))
+	for _, apiDatasource := range apiDatasources.Value {
+		allDatasources = append(allDatasources, map[string]interface{}{
+			"datasource_id": apiDatasource.DatasourceID,
+			"gateway_id":    apiDatasource.GatewayID,
+			"type":          apiDatasource.DatasourceType,
+			"database":      nilToEmptyString(apiDatasource.ConnectionDetails.Database),
+			"server":        nilToEmptyString(apiDatasource.ConnectionDetails.Server),
+			"url":           nilToEmptyString(apiDatasource.ConnectionDetails.URL),
+		})
+	}
+
+	// Datasource updates are "find and replace", so for each datasource in state we look for
+	// the datasource it was replaced with. If it is not there we fall back to the datasource
+	// it originally replaced, and failing that the only datasource of the same type. Whatever
+	// is found is written back to state so drift is visible in the plan
+	datasources := make([]interface{}, 0)
+	for _, stateDatasource := range d.Get("datasource").(*schema.Set).List() {
+		stateDatasourceObj := stateDatasource.(map[string]interface{})
+		datasourceType := stateDatasourceObj["type"].(string)
+
+		apiDatasource := findDatasourceInGroup(apiDatasources.Value, datasourceType,
+			stateDatasourceObj["server"].(string), stateDatasourceObj["database"].(string), stateDatasourceObj["url"].(string))
+		if apiDatasource == nil {
+			apiDatasource = findDatasourceInGroup(apiDatasources.Value, datasourceType,
+				stateDatasourceObj["original_server"].(string), stateDatasourceObj["original_database"].(string), stateDatasourceObj["original_url"].(string))
+		}
+		if apiDatasource == nil {
+			apiDatasource = findOnlyDatasourceInGroupOfType(apiDatasources.Value, datasourceType)
+		}
+		if apiDatasource == nil {
+			continue
+		}
+
+		if stateDatasourceObj["server"] != "" {
+			stateDatasourceObj["server"] = nilToEmptyString(apiDatasource.ConnectionDetails.Server)
+		}
+		if stateDatasourceObj["database"] != "" {
+			stateDatasourceObj["database"] = nilToEmptyString(apiDatasource.ConnectionDetails.Database)
+		}
+		if stateDatasourceObj["url"] != "" {
+			stateDatasourceObj["url"] = nilToEmptyString(apiDatasource.ConnectionDetails.URL)
+		}
+		datasources = append(datasources, stateDatasourceObj)
+	}
+
+	d.Set("datasource", datasources)
+	d.Set("all_datasources", allDatasources)
+	return nil
+}
+
+func updateDatasetDatasources(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("datasource") {
+		err := takeOverDatasetIfRequired(d, meta)
+		if err != nil {
+			return err
+		}
+
+		err = setDatasetDatasources(d, meta)
+		if err != nil {
+			return err
+		}
+	}
+
+	return readDatasetDatasources(d, meta)
+}
+
+func deleteDatasetDatasources(d *schema.ResourceData, meta interface{}) error {
+	// Datasources cannot be removed from a dataset, they remain at their last value
+	d.SetId("")
+	return nil
+}
+
+func setDatasetDatasources(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+	datasetID := d.Get("dataset_id").(string)
+	datasourceList := d.Get("datasource").(*schema.Set).List()
+	request := buildUpdateDatasourcesInGroupRequest(datasourceList)
+
+	// When a datasource has drifted the original values no longer exist on the dataset,
+	// so the values last read from the dataset are used to select it instead
+	oldDatasources, _ := d.GetChange("datasource")
+	for i, datasourceObj := range datasourceList {
+		datasourceObj := datasourceObj.(map[string]interface{})
+		for _, oldDatasourceObj := range oldDatasources.(*schema.Set).List() {
+			oldDatasourceObj := oldDatasourceObj.(map[string]interface{})
+			if oldDatasourceObj["type"] == datasourceObj["type"] &&
+				oldDatasourceObj["original_url"] == datasourceObj["original_url"] &&
+				oldDatasourceObj["original_server"] == datasourceObj["original_server"] &&
+				oldDatasourceObj["original_database"] == datasourceObj["original_database"] {
+				request.UpdateDetails[i].DatasourceSelector.ConnectionDetails = powerbiapi.UpdateDatasourcesInGroupRequestItemConnectionDetails{
+					URL:      emptyStringToNil(oldDatasourceObj["url"].(string)),
+					Database: emptyStringToNil(oldDatasourceObj["database"].(string)),
+					Server:   emptyStringToNil(oldDatasourceObj["server"].(string)),
+				}
+			}
+		}
+	}
+
+	return client.UpdateDatasourcesInGroup(groupID, datasetID, request)
+}
+
+func findDatasourceInGroup(datasources []powerbiapi.GetDatasourcesInGroupResponseItem, datasourceType string, server string, database string, url string) *powerbiapi.GetDatasourcesInGroupResponseItem {
+	if server == "" && database == "" && url == "" {
+		return nil
+	}
+
+	for i, datasource := range datasources {
+		if (datasourceType == "" || strings.EqualFold(datasourceType, datasource.DatasourceType)) &&
+			(server == "" || server == nilToEmptyString(datasource.ConnectionDetails.Server)) &&
+			(database == "" || database == nilToEmptyString(datasource.ConnectionDetails.Database)) &&
+			(url == "" || url == nilToEmptyString(datasource.ConnectionDetails.URL)) {
+			return &datasources[i]
+		}
+	}
+	return nil
+}
+
+func findOnlyDatasourceInGroupOfType(datasources []powerbiapi.GetDatasourcesInGroupResponseItem, datasourceType string) *powerbiapi.GetDatasourcesInGroupResponseItem {
+	var found *powerbiapi.GetDatasourcesInGroupResponseItem
+	for i, datasource := range datasources {
+		if datasourceType == "" || strings.EqualFold(datasourceType, datasource.DatasourceType) {
+			if found != nil {
+				return nil
+			}
+			found = &datasources[i]
+		}
+	}
+	return found
+}
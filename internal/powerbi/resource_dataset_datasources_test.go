@@ -0,0 +1,103 @@
+package powerbi
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/codecutout/terraform-provider-powerbi/internal/powerbiapi"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDatasetDatasources_basic(t *testing.T) {
+	var datasetID string
+	var groupID string
+	workspaceSuffix := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerbiWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			// first step updates datasources on a dataset deployed separately
+			{
+				Config: fmt.Sprintf(`
+				resource "powerbi_workspace" "test" {
+					name = "Acceptance Test Workspace %s"
+				}
+
+				resource "powerbi_pbix" "test" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name = "Acceptance Test PBIX"
+					source = "./resource_pbix_test_sample1.pbix"
+					source_hash = "${filemd5("./resource_pbix_test_sample1.pbix")}"
+				}
+
+				resource "powerbi_dataset_datasources" "test" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					dataset_id = "${powerbi_pbix.test.dataset_id}"
+					datasource {
+						type = "OData"
+						url = "https://services.odata.org/V3/(S(kbiqo1qkby04vnobw0li0fcp))/OData/OData.svc"
+						original_url = "https://services.odata.org/V3/OData/OData.svc"
+					}
+				}
+				`, workspaceSuffix),
+				Check: resource.ComposeTestCheckFunc(
+					set("powerbi_pbix.test", "dataset_id", &datasetID),
+					set("powerbi_pbix.test", "workspace_id", &groupID),
+					testCheckURLDatasource("powerbi_pbix.test", "https://services.odata.org/V3/(S(kbiqo1qkby04vnobw0li0fcp))/OData/OData.svc"),
+					resource.TestCheckResourceAttr("powerbi_dataset_datasources.test", "datasource.#", "1"),
+					resource.TestCheckResourceAttrSet("powerbi_dataset_datasources.test", "all_datasources.0.datasource_id"),
+				),
+			},
+			// identical definition with datasource drift is corrected
+			{
+				PreConfig: func() {
+					//update datasource outside of terraform to simulate drift
+					client := testAccProvider.Meta().(*powerbiapi.Client)
+					client.UpdateDatasourcesInGroup(groupID, datasetID, powerbiapi.UpdateDatasourcesInGroupRequest{
+						UpdateDetails: []powerbiapi.UpdateDatasourcesInGroupRequestItem{
+							{
+								ConnectionDetails: powerbiapi.UpdateDatasourcesInGroupRequestItemConnectionDetails{
+									URL: emptyStringToNil("https://services.odata.org/V4/OData/OData.svc"),
+								},
+								DatasourceSelector: powerbiapi.UpdateDatasourcesInGroupRequestItemDatasourceSelector{
+									DatasourceType: "OData",
+									ConnectionDetails: powerbiapi.UpdateDatasourcesInGroupRequestItemConnectionDetails{
+										URL: emptyStringToNil("https://services.odata.org/V3/(S(kbiqo1qkby04vnobw0li0fcp))/OData/OData.svc"),
+									},
+								},
+							},
+						},
+					})
+				},
+				Config: fmt.Sprintf(`
+				resource "powerbi_workspace" "test" {
+					name = "Acceptance Test Workspace %s"
+				}
+
+				resource "powerbi_pbix" "test" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name = "Acceptance Test PBIX"
+					source = "./resource_pbix_test_sample1.pbix"
+					source_hash = "${filemd5("./resource_pbix_test_sample1.pbix")}"
+				}
+
+				resource "powerbi_dataset_datasources" "test" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					dataset_id = "${powerbi_pbix.test.dataset_id}"
+					datasource {
+						type = "OData"
+						url = "https://services.odata.org/V3/(S(kbiqo1qkby04vnobw0li0fcp))/OData/OData.svc"
+						original_url = "https://services.odata.org/V3/OData/OData.svc"
+					}
+				}
+				`, workspaceSuffix),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckURLDatasource("powerbi_pbix.test", "https://services.odata.org/V3/(S(kbiqo1qkby04vnobw0li0fcp))/OData/OData.svc"),
+				),
+			},
+		},
+	})
+}
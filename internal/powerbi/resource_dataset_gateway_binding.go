@@ -0,0 +1,185 @@
+package powerbi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/codecutout/terraform-provider-powerbi/internal/powerbiapi"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// ResourceDatasetGatewayBinding represents the binding of a Power BI dataset to a gateway
+func ResourceDatasetGatewayBinding() *schema.Resource {
+	return &schema.Resource{
+		Create: createDatasetGatewayBinding,
+		Read:   readDatasetGatewayBinding,
+		Update: updateDatasetGatewayBinding,
+		Delete: deleteDatasetGatewayBinding,
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				client := meta.(*powerbiapi.Client)
+				idParts := strings.Split(d.Id(), "/")
+				if len(idParts) != 2 {
+					return nil, fmt.Errorf("invalid import ID, expected format: workspace_id/dataset_id or workspace_name/dataset_name")
+				}
+				groupID, err := resolveImportWorkspaceID(client, idParts[0])
+				if err != nil {
+					return nil, err
+				}
+				datasetID, err := resolveImportDatasetID(client, groupID, idParts[1])
+				if err != nil {
+					return nil, err
+				}
+				d.Set("workspace_id", groupID)
+				d.Set("dataset_id", datasetID)
+				d.SetId(fmt.Sprintf("%s/%s", groupID, datasetID))
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"workspace_id": {
+				Type:        schema.TypeString,
+				Description: "Workspace ID in which the dataset exists.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"dataset_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the dataset to bind to the gateway.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"gateway_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the gateway to bind the dataset to. When using a gateway cluster this is the ID of the primary gateway.",
+				Required:    true,
+			},
+			"datasource_ids": {
+				Type:        schema.TypeSet,
+				Description: "The IDs of the gateway datasources the dataset datasources should be bound to. If not set, the gateway datasources are matched automatically.",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"take_over": {
+				Type:        schema.TypeBool,
+				Description: "If true, the dataset will be taken over by the current user before binding. Required when the dataset is owned by another user or service principal.",
+				Optional:    true,
+				Default:     false,
+			},
+			"bound_datasource_ids": {
+				Type:        schema.TypeSet,
+				Description: "The IDs of the gateway datasources the dataset is currently bound to.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func createDatasetGatewayBinding(d *schema.ResourceData, meta interface{}) error {
+	groupID := d.Get("workspace_id").(string)
+	datasetID := d.Get("dataset_id").(string)
+
+	err := takeOverDatasetIfRequired(d, meta)
+	if err != nil {
+		return err
+	}
+
+	err = bindDatasetToGateway(d, meta)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", groupID, datasetID))
+
+	return readDatasetGatewayBinding(d, meta)
+}
+
+func readDatasetGatewayBinding(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+	datasetID := d.Get("dataset_id").(string)
+	gatewayID := d.Get("gateway_id").(string)
+
+	apiDatasources, err := client.GetDatasourcesInGroup(groupID, datasetID)
+	if powerbiapi.IsHTTP404Error(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// If the dataset is no longer bound to our gateway, report whichever gateway it is bound to
+	boundGatewayID := ""
+	for _, apiDatasource := range apiDatasources.Value {
+		if apiDatasource.GatewayID == "" {
+			continue
+		}
+		if boundGatewayID == "" || strings.EqualFold(apiDatasource.GatewayID, gatewayID) {
+			boundGatewayID = apiDatasource.GatewayID
+		}
+	}
+
+	boundDatasourceIDs := make([]interface{}, 0)
+	for _, apiDatasource := range apiDatasources.Value {
+		if apiDatasource.GatewayID != "" && strings.EqualFold(apiDatasource.GatewayID, boundGatewayID) {
+			boundDatasourceIDs = append(boundDatasourceIDs, apiDatasource.DatasourceID)
+		}
+	}
+
+	// Only track the datasource IDs that were explicitly requested
+	datasourceIDs := make([]interface{}, 0)
+	for _, datasourceID := range d.Get("datasource_ids").(*schema.Set).List() {
+		for _, boundDatasourceID := range boundDatasourceIDs {
+			if strings.EqualFold(datasourceID.(string), boundDatasourceID.(string)) {
+				datasourceIDs = append(datasourceIDs, datasourceID)
+			}
+		}
+	}
+
+	d.Set("gateway_id", boundGatewayID)
+	d.Set("datasource_ids", datasourceIDs)
+	d.Set("bound_datasource_ids", boundDatasourceIDs)
+	return nil
+}
+
+func updateDatasetGatewayBinding(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("gateway_id") || d.HasChange("datasource_ids") {
+		err := takeOverDatasetIfRequired(d, meta)
+		if err != nil {
+			return err
+		}
+
+		err = bindDatasetToGateway(d, meta)
+		if err != nil {
+			return err
+		}
+	}
+
+	return readDatasetGatewayBinding(d, meta)
+}
+
+func deleteDatasetGatewayBinding(d *schema.ResourceData, meta interface{}) error {
+	// There is no API to unbind a dataset from a gateway, the dataset stays bound
+	d.SetId("")
+	return nil
+}
+
+func bindDatasetToGateway(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+	datasetID := d.Get("dataset_id").(string)
+
+	return client.BindToGatewayInGroup(groupID, datasetID, powerbiapi.BindToGatewayInGroupRequest{
+		GatewayObjectID:     d.Get("gateway_id").(string),
+		DatasourceObjectIDs: convertToStringSlice(d.Get("datasource_ids").(*schema.Set).List()),
+	})
+}
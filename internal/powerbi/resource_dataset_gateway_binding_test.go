@@ -0,0 +1,103 @@
+package powerbi
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccDatasetGatewayBinding_basic(t *testing.T) {
+	workspaceSuffix := acctest.RandString(6)
+	datasourceName := fmt.Sprintf("tftest%s", acctest.RandString(6))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerbiWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			// first step binds the OData datasource of the PBIX to the gateway datasource
+			{
+				Config: testAccDatasetGatewayBindingConfig(workspaceSuffix, datasourceName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("powerbi_dataset_gateway_binding.test", "gateway_id", "data.powerbi_gateway.test", "id"),
+					resource.TestCheckResourceAttr("powerbi_dataset_gateway_binding.test", "datasource_ids.#", "1"),
+					resource.TestCheckResourceAttr("powerbi_dataset_gateway_binding.test", "bound_datasource_ids.#", "1"),
+					testCheckDatasetGatewayBindingDatasource("powerbi_dataset_gateway_binding.test", "powerbi_gateway_datasource.test"),
+				),
+			},
+			// import reads the gateway from the dataset, datasource_ids is only tracked when set in config
+			{
+				ResourceName:            "powerbi_dataset_gateway_binding.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"datasource_ids", "take_over"},
+			},
+		},
+	})
+}
+
+func testCheckDatasetGatewayBindingDatasource(bindingResourceName string, datasourceResourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		datasourceID, err := getResourceID(s, datasourceResourceName)
+		if err != nil {
+			return err
+		}
+		rs, ok := s.RootModule().Resources[bindingResourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", bindingResourceName)
+		}
+
+		// datasource_ids is a set so is keyed by hash rather than index
+		for key, value := range rs.Primary.Attributes {
+			if strings.HasPrefix(key, "datasource_ids.") && key != "datasource_ids.#" && value == datasourceID {
+				return nil
+			}
+		}
+		return fmt.Errorf("expected dataset to be bound to datasource %s", datasourceID)
+	}
+}
+
+func testAccDatasetGatewayBindingConfig(workspaceSuffix string, datasourceName string) string {
+	return fmt.Sprintf(`
+	resource "powerbi_workspace" "test" {
+		name = "Acceptance Test Workspace %s"
+	}
+
+	resource "powerbi_pbix" "test" {
+		workspace_id = "${powerbi_workspace.test.id}"
+		name = "Acceptance Test PBIX"
+		source = "./resource_pbix_test_sample1.pbix"
+		source_hash = "${filemd5("./resource_pbix_test_sample1.pbix")}"
+	}
+
+	data "powerbi_gateway" "test" {
+		name = "TestGateway"
+	}
+
+	resource "powerbi_gateway_datasource" "test" {
+		gateway_id = "${data.powerbi_gateway.test.id}"
+		datasource_name = "%s"
+		datasource_type = "OData"
+		credential_type = "Anonymous"
+
+		connection_details {
+			url = "https://services.odata.org/V3/OData/OData.svc"
+		}
+
+		credential_details {
+			privacy_level = "Public"
+		}
+	}
+
+	resource "powerbi_dataset_gateway_binding" "test" {
+		workspace_id = "${powerbi_workspace.test.id}"
+		dataset_id = "${powerbi_pbix.test.dataset_id}"
+		gateway_id = "${data.powerbi_gateway.test.id}"
+		datasource_ids = ["${powerbi_gateway_datasource.test.id}"]
+	}
+	`, workspaceSuffix, datasourceName)
+}
@@ -0,0 +1,210 @@
+package powerbi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/codecutout/terraform-provider-powerbi/internal/powerbiapi"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// ResourceDatasetParameters represents the parameters of an existing Power BI dataset
+func ResourceDatasetParameters() *schema.Resource {
+	return &schema.Resource{
+		Create: createDatasetParameters,
+		Read:   readDatasetParameters,
+		Update: updateDatasetParameters,
+		Delete: deleteDatasetParameters,
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				client := meta.(*powerbiapi.Client)
+				idParts := strings.Split(d.Id(), "/")
+				if len(idParts) != 2 {
+					return nil, fmt.Errorf("invalid import ID, expected format: workspace_id/dataset_id or workspace_name/dataset_name")
+				}
+				groupID, err := resolveImportWorkspaceID(client, idParts[0])
+				if err != nil {
+					return nil, err
+				}
+				datasetID, err := resolveImportDatasetID(client, groupID, idParts[1])
+				if err != nil {
+					return nil, err
+				}
+				d.Set("workspace_id", groupID)
+				d.Set("dataset_id", datasetID)
+				d.SetId(fmt.Sprintf("%s/%s", groupID, datasetID))
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"workspace_id": {
+				Type:        schema.TypeString,
+				Description: "Workspace ID in which the dataset exists.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"dataset_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the dataset whose parameters will be managed. The dataset can be deployed by any means.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"take_over": {
+				Type:        schema.TypeBool,
+				Description: "If true, the dataset will be taken over by the current user before updating parameters. Required when the dataset is owned by another user or service principal.",
+				Optional:    true,
+				Default:     false,
+			},
+			"parameter": {
+				Type:        schema.TypeSet,
+				Description: "Parameters to be configured on the dataset. Any parameters not mentioned will not be updated",
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The parameter name",
+							Required:    true,
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Description: "The parameter value",
+							Required:    true,
+						},
+					},
+				},
+			},
+			"all_parameters": {
+				Type:        schema.TypeList,
+				Description: "All parameters currently defined on the dataset, including those not managed by this resource.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The parameter name",
+							Computed:    true,
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Description: "The parameter type",
+							Computed:    true,
+						},
+						"is_required": {
+							Type:        schema.TypeBool,
+							Description: "Whether the parameter is required",
+							Computed:    true,
+						},
+						"current_value": {
+							Type:        schema.TypeString,
+							Description: "The current value of the parameter",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func createDatasetParameters(d *schema.ResourceData, meta interface{}) error {
+	groupID := d.Get("workspace_id").(string)
+	datasetID := d.Get("dataset_id").(string)
+
+	err := takeOverDatasetIfRequired(d, meta)
+	if err != nil {
+		return err
+	}
+
+	err = setDatasetParameters(d, meta)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", groupID, datasetID))
+
+	return readDatasetParameters(d, meta)
+}
+
+func readDatasetParameters(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+	datasetID := d.Get("dataset_id").(string)
+
+	apiParameters, err := client.GetParametersInGroup(groupID, datasetID)
+	if powerbiapi.IsHTTP404Error(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// Only parameters that are managed are tracked. Parameters that no longer
+	// exist on the dataset are removed from state so they are recreated on the next apply.
+	// When nothing is tracked (e.g. after import) all parameters are tracked
+	stateParameters := d.Get("parameter").(*schema.Set).List()
+	trackAll := len(stateParameters) == 0
+	parameters := make([]interface{}, 0)
+	allParameters := make([]interface{}, 0, len(apiParameters.Value))
+	for _, apiParameter := range apiParameters.Value {
+		allParameters = append(allParameters, map[string]interface{}{
+			"name":          apiParameter.Name,
+			"type":          apiParameter.Type,
+			"is_required":   apiParameter.IsRequired,
+			"current_value": apiParameter.CurrentValue,
+		})
+
+		isTracked := trackAll
+		for _, stateParameter := range stateParameters {
+			if stateParameter.(map[string]interface{})["name"] == apiParameter.Name {
+				isTracked = true
+			}
+		}
+		if isTracked {
+			parameters = append(parameters, map[string]interface{}{
+				"name":  apiParameter.Name,
+				"value": apiParameter.CurrentValue,
+			})
+		}
+	}
+
+	d.Set("parameter", parameters)
+	d.Set("all_parameters", allParameters)
+	return nil
+}
+
+func updateDatasetParameters(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("parameter") {
+		err := takeOverDatasetIfRequired(d, meta)
+		if err != nil {
+			return err
+		}
+
+		err = setDatasetParameters(d, meta)
+		if err != nil {
+			return err
+		}
+	}
+
+	return readDatasetParameters(d, meta)
+}
+
+func deleteDatasetParameters(d *schema.ResourceData, meta interface{}) error {
+	// Parameters cannot be removed from a dataset, they remain at their last value
+	d.SetId("")
+	return nil
+}
+
+func setDatasetParameters(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+	datasetID := d.Get("dataset_id").(string)
+	parameterList := d.Get("parameter").(*schema.Set).List()
+
+	return client.UpdateParametersInGroup(groupID, datasetID, buildUpdateParametersInGroupRequest(parameterList))
+}
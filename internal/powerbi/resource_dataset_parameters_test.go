@@ -0,0 +1,100 @@
+package powerbi
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/codecutout/terraform-provider-powerbi/internal/powerbiapi"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDatasetParameters_basic(t *testing.T) {
+	var datasetID string
+	var groupID string
+	workspaceSuffix := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerbiWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			// first step sets parameters on a dataset deployed separately
+			{
+				Config: fmt.Sprintf(`
+				resource "powerbi_workspace" "test" {
+					name = "Acceptance Test Workspace %s"
+				}
+
+				resource "powerbi_pbix" "test" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name = "Acceptance Test PBIX"
+					source = "./resource_pbix_test_sample1.pbix"
+					source_hash = "${filemd5("./resource_pbix_test_sample1.pbix")}"
+				}
+
+				resource "powerbi_dataset_parameters" "test" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					dataset_id = "${powerbi_pbix.test.dataset_id}"
+					parameter {
+						name = "ParamOne"
+						value = "NewParamValueOne"
+					}
+				}
+				`, workspaceSuffix),
+				Check: resource.ComposeTestCheckFunc(
+					set("powerbi_pbix.test", "dataset_id", &datasetID),
+					set("powerbi_pbix.test", "workspace_id", &groupID),
+					testCheckParameter("powerbi_pbix.test", "ParamOne", "NewParamValueOne"),
+					resource.TestCheckResourceAttr("powerbi_dataset_parameters.test", "parameter.#", "1"),
+					resource.TestCheckResourceAttr("powerbi_dataset_parameters.test", "all_parameters.#", "2"),
+				),
+			},
+			// identical definition with parameter drift is corrected
+			{
+				PreConfig: func() {
+					//update parameter outside of terraform to simulate drift
+					client := testAccProvider.Meta().(*powerbiapi.Client)
+					client.UpdateParametersInGroup(groupID, datasetID, powerbiapi.UpdateParametersInGroupRequest{
+						UpdateDetails: []powerbiapi.UpdateParametersInGroupRequestItem{
+							{
+								Name:     "ParamOne",
+								NewValue: "DriftedValue",
+							},
+						},
+					})
+				},
+				Config: fmt.Sprintf(`
+				resource "powerbi_workspace" "test" {
+					name = "Acceptance Test Workspace %s"
+				}
+
+				resource "powerbi_pbix" "test" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name = "Acceptance Test PBIX"
+					source = "./resource_pbix_test_sample1.pbix"
+					source_hash = "${filemd5("./resource_pbix_test_sample1.pbix")}"
+				}
+
+				resource "powerbi_dataset_parameters" "test" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					dataset_id = "${powerbi_pbix.test.dataset_id}"
+					parameter {
+						name = "ParamOne"
+						value = "NewParamValueOne"
+					}
+				}
+				`, workspaceSuffix),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckParameter("powerbi_pbix.test", "ParamOne", "NewParamValueOne"),
+				),
+			},
+			{
+				ResourceName:            "powerbi_dataset_parameters.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"parameter", "take_over"},
+			},
+		},
+	})
+}
@@ -0,0 +1,188 @@
+package powerbi
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/codecutout/terraform-provider-powerbi/internal/powerbiapi"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// ResourceDatasetTakeover represents the ownership of a Power BI dataset or paginated report
+func ResourceDatasetTakeover() *schema.Resource {
+	return &schema.Resource{
+		Create: createDatasetTakeover,
+		Read:   readDatasetTakeover,
+		Delete: deleteDatasetTakeover,
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				client := meta.(*powerbiapi.Client)
+				idParts := strings.Split(d.Id(), "/")
+				isPaginatedReport := len(idParts) == 3 && idParts[1] == "paginated_report"
+				if len(idParts) != 2 && !isPaginatedReport {
+					return nil, fmt.Errorf("invalid import ID, expected format: workspace_id/dataset_id, workspace_id/paginated_report/report_id, workspace_name/dataset_name or workspace_name/paginated_report/report_name")
+				}
+				groupID, err := resolveImportWorkspaceID(client, idParts[0])
+				if err != nil {
+					return nil, err
+				}
+				d.Set("workspace_id", groupID)
+
+				// paginated reports are marked so their IDs are not mistaken for dataset IDs
+				if isPaginatedReport {
+					reportID, err := resolveImportReportID(client, groupID, idParts[2], "PaginatedReport")
+					if err != nil {
+						return nil, err
+					}
+					d.Set("paginated_report_id", reportID)
+					d.SetId(fmt.Sprintf("%s/%s", groupID, reportID))
+					return []*schema.ResourceData{d}, nil
+				}
+
+				datasetID, err := resolveImportDatasetID(client, groupID, idParts[1])
+				if err != nil {
+					return nil, err
+				}
+				d.Set("dataset_id", datasetID)
+				d.SetId(fmt.Sprintf("%s/%s", groupID, datasetID))
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"workspace_id": {
+				Type:        schema.TypeString,
+				Description: "Workspace ID in which the dataset or paginated report exists.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"dataset_id": {
+				Type:         schema.TypeString,
+				Description:  "The ID of the dataset to take over.",
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"dataset_id", "paginated_report_id"},
+			},
+			"paginated_report_id": {
+				Type:         schema.TypeString,
+				Description:  "The ID of the paginated report to take over.",
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"dataset_id", "paginated_report_id"},
+			},
+			"configured_by": {
+				Type:        schema.TypeString,
+				Description: "The current owner of the dataset. If ownership changes outside of Terraform the dataset will be taken over again on the next apply. Not available for paginated reports.",
+				Computed:    true,
+			},
+			"taken_over_by": {
+				Type:        schema.TypeString,
+				Description: "The owner of the dataset immediately after it was taken over.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func createDatasetTakeover(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+
+	if reportID, ok := d.GetOk("paginated_report_id"); ok {
+		err := client.TakeOverReportInGroup(groupID, reportID.(string))
+		if err != nil {
+			return err
+		}
+
+		d.SetId(fmt.Sprintf("%s/%s", groupID, reportID.(string)))
+		return readDatasetTakeover(d, meta)
+	}
+
+	datasetID := d.Get("dataset_id").(string)
+	err := client.TakeOverInGroup(groupID, datasetID)
+	if err != nil {
+		return err
+	}
+
+	dataset, err := client.GetDatasetInGroup(groupID, datasetID)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", groupID, datasetID))
+	d.Set("taken_over_by", dataset.ConfiguredBy)
+
+	return readDatasetTakeover(d, meta)
+}
+
+func readDatasetTakeover(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+
+	if reportID, ok := d.GetOk("paginated_report_id"); ok {
+		report, err := client.GetReportInGroup(groupID, reportID.(string))
+		if powerbiapi.IsHTTP404Error(err) {
+			d.SetId("")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if !report.IsOwnedByMe {
+			log.Printf("[INFO] Paginated report %s is no longer owned by the current user, it will be taken over again", reportID)
+			d.SetId("")
+		}
+		return nil
+	}
+
+	dataset, err := client.GetDatasetInGroup(groupID, d.Get("dataset_id").(string))
+	if powerbiapi.IsHTTP404Error(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	d.Set("configured_by", dataset.ConfiguredBy)
+
+	// when imported we do not know who took over the dataset, so assume the current owner
+	takenOverBy := d.Get("taken_over_by").(string)
+	if takenOverBy == "" {
+		d.Set("taken_over_by", dataset.ConfiguredBy)
+	} else if !strings.EqualFold(takenOverBy, dataset.ConfiguredBy) {
+		log.Printf("[INFO] Dataset %s is now configured by %s instead of %s, it will be taken over again", d.Get("dataset_id"), dataset.ConfiguredBy, takenOverBy)
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func deleteDatasetTakeover(d *schema.ResourceData, meta interface{}) error {
+	// Ownership cannot be given back, the dataset stays with its current owner
+	d.SetId("")
+	return nil
+}
+
+func takeOverDatasetIfRequired(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	if !d.Get("take_over").(bool) {
+		return nil
+	}
+
+	groupID := d.Get("workspace_id").(string)
+	datasetID := d.Get("dataset_id").(string)
+
+	// some pbix do not have datasets, so there is nothing to take over. Datasets in
+	// My workspace have no group and are always owned by the current user
+	if datasetID == "" || groupID == "" {
+		return nil
+	}
+
+	return client.TakeOverInGroup(groupID, datasetID)
+}
@@ -0,0 +1,46 @@
+package powerbi
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDatasetTakeover_basic(t *testing.T) {
+	workspaceSuffix := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerbiWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "powerbi_workspace" "test" {
+					name = "Acceptance Test Workspace %s"
+				}
+
+				resource "powerbi_pbix" "test" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name = "Acceptance Test PBIX"
+					source = "./resource_pbix_test_sample1.pbix"
+					source_hash = "${filemd5("./resource_pbix_test_sample1.pbix")}"
+					take_over = true
+				}
+
+				resource "powerbi_dataset_takeover" "test" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					dataset_id = "${powerbi_pbix.test.dataset_id}"
+				}
+				`, workspaceSuffix),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("powerbi_pbix.test", "configured_by"),
+					resource.TestCheckResourceAttrSet("powerbi_dataset_takeover.test", "configured_by"),
+					resource.TestCheckResourceAttrPair("powerbi_dataset_takeover.test", "configured_by", "powerbi_dataset_takeover.test", "taken_over_by"),
+				),
+			},
+		},
+	})
+}
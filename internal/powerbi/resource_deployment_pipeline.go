@@ -11,12 +11,22 @@ import (
 // ResourceDeploymentPipeline represents a Power BI deployment pipeline
 func ResourceDeploymentPipeline() *schema.Resource {
 	return &schema.Resource{
+		Description: "represents a Power BI deployment pipeline and its users.",
+
 		Create: createDeploymentPipeline,
 		Read:   readDeploymentPipeline,
 		Update: updateDeploymentPipeline,
 		Delete: deleteDeploymentPipeline,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				client := meta.(*powerbiapi.Client)
+				pipelineID, err := resolveImportPipelineID(client, d.Id())
+				if err != nil {
+					return nil, err
+				}
+				d.SetId(pipelineID)
+				return []*schema.ResourceData{d}, nil
+			},
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -18,12 +18,21 @@ func ResourceGatewayDatasource() *schema.Resource {
 		Delete: deleteGatewayDatasource,
 		Importer: &schema.ResourceImporter{
 			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				client := meta.(*powerbiapi.Client)
 				parts := strings.Split(d.Id(), "/")
 				if len(parts) != 2 {
-					return nil, fmt.Errorf("invalid datasource import id format, expected 'gateway_id/datasource_id'")
+					return nil, fmt.Errorf("invalid datasource import id format, expected 'gateway_id/datasource_id' or 'gateway_name/datasource_name'")
 				}
-				d.Set("gateway_id", parts[0])
-				d.SetId(parts[1])
+				gatewayID, err := resolveImportGatewayID(client, parts[0])
+				if err != nil {
+					return nil, err
+				}
+				datasourceID, err := resolveImportGatewayDatasourceID(client, gatewayID, parts[1])
+				if err != nil {
+					return nil, err
+				}
+				d.Set("gateway_id", gatewayID)
+				d.SetId(datasourceID)
 				return []*schema.ResourceData{d}, nil
 			},
 		},
@@ -45,7 +54,7 @@ func ResourceGatewayDatasource() *schema.Resource {
 				Type:        schema.TypeString,
 				Required:    true,
 				ForceNew:    true,
-				Description: "Type of the datasource.",
+				Description: "Type of the datasource. Supported types: `Sql`, `Oracle`, `OleDb`, `ODBC`, `SharePointList`, `Web`, `OData`, `File`, `Folder`, `SharePointDocumentLibrary`, `Hdfs`, `AzureTable`, `Exchange`, `ActiveDirectory`, `MySql`, `PostgreSql`, `Sybase`, `DB2`, `Teradata`, `SapHana`, `SapBw`, `AnalysisServices`, `AzureBlob`, `AzureSql`, `AzureSqlDw`, `Informix`, `GoogleAnalytics`, `AmazonRedshift`, `Impala`, `Spark`, `Smartsheet`.",
 				ValidateFunc: validation.StringInSlice([]string{
 					"Sql", "Oracle", "OleDb", "ODBC", "SharePointList", "Web",
 					"OData", "File", "Folder", "SharePointDocumentLibrary",
@@ -125,7 +134,7 @@ func ResourceGatewayDatasource() *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Default:     "Basic",
-				Description: "Type of credentials used for authentication.",
+				Description: "Type of credentials used for authentication. Options: `Basic`, `Windows`, `OAuth2`, `Anonymous`, `Key`.",
 				ValidateFunc: validation.StringInSlice([]string{
 					"Basic", "Windows", "OAuth2", "Anonymous", "Key",
 				}, false),
@@ -143,6 +152,36 @@ func ResourceGatewayDatasource() *schema.Resource {
 							Optional:    true,
 							Sensitive:   true,
 							Description: "Encrypted credentials.",
+							ConflictsWith: []string{
+								"credential_details.0.username",
+								"credential_details.0.password",
+								"credential_details.0.key",
+								"credential_details.0.access_token",
+							},
+						},
+						"username": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "Username for `Basic` and `Windows` credentials. Encrypted with the gateway public key by the provider.",
+						},
+						"password": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "Password for `Basic` and `Windows` credentials. Encrypted with the gateway public key by the provider.",
+						},
+						"key": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "Key for `Key` credentials. Encrypted with the gateway public key by the provider.",
+						},
+						"access_token": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "Access token for `OAuth2` credentials. Encrypted with the gateway public key by the provider.",
 						},
 						"encrypted_connection": {
 							Type:        schema.TypeString,
@@ -152,13 +191,13 @@ func ResourceGatewayDatasource() *schema.Resource {
 						"encryption_algorithm": {
 							Type:        schema.TypeString,
 							Optional:    true,
-							Description: "Encryption algorithm used.",
+							Description: "Encryption algorithm used. Set to `RSA-OAEP` automatically when plaintext credentials are provided.",
 						},
 						"privacy_level": {
 							Type:        schema.TypeString,
 							Optional:    true,
 							Default:     "None",
-							Description: "Privacy level for the datasource.",
+							Description: "Privacy level for the datasource. Options: `None`, `Public`, `Organizational`, `Private`.",
 							ValidateFunc: validation.StringInSlice([]string{
 								"None", "Public", "Organizational", "Private",
 							}, false),
@@ -201,7 +240,11 @@ func createGatewayDatasource(d *schema.ResourceData, meta interface{}) error {
 	}
 	
 	if credDetails, ok := d.GetOk("credential_details"); ok {
-		request.CredentialDetails = buildCredentialDetails(credDetails.([]interface{}), d.Get("credential_type").(string))
+		credentialDetails, err := buildCredentialDetails(client, gatewayID, credDetails.([]interface{}), d.Get("credential_type").(string))
+		if err != nil {
+			return err
+		}
+		request.CredentialDetails = credentialDetails
 	}
 	
 	datasource, err := client.CreateDatasource(gatewayID, request)
@@ -273,7 +316,11 @@ func updateGatewayDatasource(d *schema.ResourceData, meta interface{}) error {
 		request := powerbiapi.UpdateDatasourceRequest{}
 		
 		if credDetails, ok := d.GetOk("credential_details"); ok {
-			request.CredentialDetails = buildCredentialDetails(credDetails.([]interface{}), d.Get("credential_type").(string))
+			credentialDetails, err := buildCredentialDetails(client, gatewayID, credDetails.([]interface{}), d.Get("credential_type").(string))
+			if err != nil {
+				return err
+			}
+			request.CredentialDetails = credentialDetails
 		}
 		
 		err := client.UpdateDatasource(gatewayID, datasourceID, request)
@@ -327,14 +374,14 @@ func buildConnectionDetails(details []interface{}) powerbiapi.DatasourceConnecti
 	}
 }
 
-func buildCredentialDetails(details []interface{}, credentialType string) *powerbiapi.DatasourceCredentialDetails {
+func buildCredentialDetails(client *powerbiapi.Client, gatewayID string, details []interface{}, credentialType string) (*powerbiapi.DatasourceCredentialDetails, error) {
 	if len(details) == 0 {
-		return nil
+		return nil, nil
 	}
 	
 	detail := details[0].(map[string]interface{})
 	
-	return &powerbiapi.DatasourceCredentialDetails{
+	credentialDetails := &powerbiapi.DatasourceCredentialDetails{
 		CredentialType:              credentialType,
 		Credentials:                 getStringValue(detail, "credentials"),
 		EncryptedConnection:         getStringValue(detail, "encrypted_connection"),
@@ -343,6 +390,69 @@ func buildCredentialDetails(details []interface{}, credentialType string) *power
 		UseCallerAADIdentity:        getBoolValue(detail, "use_caller_aad_identity"),
 		UseEndUserOAuth2Credentials: getBoolValue(detail, "use_end_user_oauth2_credentials"),
 	}
+	
+	plaintextCredentials, err := buildPlaintextCredentials(detail, credentialType)
+	if err != nil {
+		return nil, err
+	}
+	if plaintextCredentials == "" {
+		return credentialDetails, nil
+	}
+	
+	if credentialDetails.Credentials != "" {
+		return nil, fmt.Errorf("credentials cannot be set together with username, password, key or access_token")
+	}
+	
+	gateway, err := client.GetGateway(gatewayID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public key of gateway %s: %w", gatewayID, err)
+	}
+	
+	credentialDetails.Credentials, err = powerbiapi.EncryptCredentials(plaintextCredentials, gateway.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt credentials: %w", err)
+	}
+	if credentialDetails.EncryptionAlgorithm == "" {
+		credentialDetails.EncryptionAlgorithm = "RSA-OAEP"
+	}
+	
+	return credentialDetails, nil
+}
+
+// buildPlaintextCredentials returns the unencrypted credentials JSON built from the structured
+// credential attributes, or an empty string if no structured credentials were provided
+func buildPlaintextCredentials(detail map[string]interface{}, credentialType string) (string, error) {
+	username := getStringValue(detail, "username")
+	password := getStringValue(detail, "password")
+	key := getStringValue(detail, "key")
+	accessToken := getStringValue(detail, "access_token")
+	
+	if username == "" && password == "" && key == "" && accessToken == "" {
+		return "", nil
+	}
+	
+	switch credentialType {
+	case "Basic", "Windows":
+		if username == "" || password == "" || key != "" || accessToken != "" {
+			return "", fmt.Errorf("%s credentials require username and password only", credentialType)
+		}
+		if credentialType == "Windows" {
+			return powerbiapi.BuildWindowsCredentials(username, password)
+		}
+		return powerbiapi.BuildBasicCredentials(username, password)
+	case "Key":
+		if key == "" || username != "" || password != "" || accessToken != "" {
+			return "", fmt.Errorf("%s credentials require key only", credentialType)
+		}
+		return powerbiapi.BuildKeyCredentials(key)
+	case "OAuth2":
+		if accessToken == "" || username != "" || password != "" || key != "" {
+			return "", fmt.Errorf("%s credentials require access_token only", credentialType)
+		}
+		return powerbiapi.BuildOAuth2Credentials(accessToken)
+	default:
+		return "", fmt.Errorf("%s credentials do not support username, password, key or access_token", credentialType)
+	}
 }
 
 func getStringValue(m map[string]interface{}, key string) string {
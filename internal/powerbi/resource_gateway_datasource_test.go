@@ -31,6 +31,93 @@ func TestAccGatewayDatasource_basic(t *testing.T) {
 	})
 }
 
+func TestAccGatewayDatasource_plaintextCredentials(t *testing.T) {
+	datasourceName := fmt.Sprintf("tftest%s", acctest.RandString(6))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGatewayDatasourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGatewayDatasourceConfig_plaintextCredentials(datasourceName, "password1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGatewayDatasourceExists("powerbi_gateway_datasource.test"),
+					resource.TestCheckResourceAttr("powerbi_gateway_datasource.test", "credential_type", "Basic"),
+				),
+			},
+			{
+				Config: testAccGatewayDatasourceConfig_plaintextCredentials(datasourceName, "password2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGatewayDatasourceExists("powerbi_gateway_datasource.test"),
+					resource.TestCheckResourceAttr("powerbi_gateway_datasource.test", "credential_type", "Basic"),
+				),
+			},
+		},
+	})
+}
+
+func TestGatewayDatasourceCredentialsConflict(t *testing.T) {
+	for _, key := range []string{"", "username", "password", "key", "access_token"} {
+		credentialDetails := map[string]interface{}{"credentials": "encrypted"}
+		if key != "" {
+			credentialDetails[key] = "value"
+		}
+		raw := map[string]interface{}{
+			"gateway_id":         "gateway",
+			"datasource_name":    "datasource",
+			"datasource_type":    "Sql",
+			"connection_details": []interface{}{map[string]interface{}{"server": "localhost"}},
+			"credential_details": []interface{}{credentialDetails},
+		}
+
+		_, errs := ResourceGatewayDatasource().Validate(terraform.NewResourceConfigRaw(raw))
+		if key == "" && len(errs) != 0 {
+			t.Errorf("expected credentials alone to be valid, got %v", errs)
+		}
+		if key != "" && len(errs) != 1 {
+			t.Errorf("expected credentials to conflict with %s, got %v", key, errs)
+		}
+	}
+}
+
+func TestBuildPlaintextCredentials(t *testing.T) {
+	tests := []struct {
+		name           string
+		detail         map[string]interface{}
+		credentialType string
+		expected       string
+		expectError    bool
+	}{
+		{"NoPlaintext", map[string]interface{}{"credentials": "encrypted"}, "Basic", "", false},
+		{"Basic", map[string]interface{}{"username": "user", "password": "pass"}, "Basic", `{"credentialData":[{"name":"username","value":"user"},{"name":"password","value":"pass"}]}`, false},
+		{"Windows", map[string]interface{}{"username": "user", "password": "pass"}, "Windows", `{"credentialData":[{"name":"username","value":"user"},{"name":"password","value":"pass"}]}`, false},
+		{"Key", map[string]interface{}{"key": "secret"}, "Key", `{"credentialData":[{"name":"key","value":"secret"}]}`, false},
+		{"OAuth2", map[string]interface{}{"access_token": "token"}, "OAuth2", `{"credentialData":[{"name":"accessToken","value":"token"}]}`, false},
+		{"BasicMissingPassword", map[string]interface{}{"username": "user"}, "Basic", "", true},
+		{"KeyWithUsername", map[string]interface{}{"key": "secret", "username": "user"}, "Key", "", true},
+		{"Anonymous", map[string]interface{}{"username": "user", "password": "pass"}, "Anonymous", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := buildPlaintextCredentials(tt.detail, tt.credentialType)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, actual)
+			}
+		})
+	}
+}
+
 func TestAccGatewayDatasource_importBasic(t *testing.T) {
 	datasourceName := fmt.Sprintf("tftest%s", acctest.RandString(6))
 
@@ -116,4 +203,32 @@ resource "powerbi_gateway_datasource" "test" {
   }
 }
 `, datasourceName)
-}
\ No newline at end of file
+}
+
+func testAccGatewayDatasourceConfig_plaintextCredentials(datasourceName string, password string) string {
+	return fmt.Sprintf(`
+data "powerbi_gateway" "test" {
+  name = "TestGateway"
+}
+
+resource "powerbi_gateway_datasource" "test" {
+  gateway_id      = data.powerbi_gateway.test.id
+  datasource_name = "%s"
+  datasource_type = "Sql"
+
+  connection_details {
+    server   = "localhost"
+    database = "TestDB"
+  }
+
+  credential_type = "Basic"
+
+  credential_details {
+    username             = "testuser"
+    password             = "%s"
+    encrypted_connection = "Encrypted"
+    privacy_level        = "Organizational"
+  }
+}
+`, datasourceName, password)
+}
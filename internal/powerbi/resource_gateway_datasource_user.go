@@ -17,12 +17,21 @@ func ResourceGatewayDatasourceUser() *schema.Resource {
 		Delete: deleteGatewayDatasourceUser,
 		Importer: &schema.ResourceImporter{
 			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				client := meta.(*powerbiapi.Client)
 				parts := strings.Split(d.Id(), "/")
 				if len(parts) != 3 {
-					return nil, fmt.Errorf("invalid datasource user import id format, expected 'gateway_id/datasource_id/user_id'")
+					return nil, fmt.Errorf("invalid datasource user import id format, expected 'gateway_id/datasource_id/user_id' or 'gateway_name/datasource_name/user_id'")
 				}
-				d.Set("gateway_id", parts[0])
-				d.Set("datasource_id", parts[1])
+				gatewayID, err := resolveImportGatewayID(client, parts[0])
+				if err != nil {
+					return nil, err
+				}
+				datasourceID, err := resolveImportGatewayDatasourceID(client, gatewayID, parts[1])
+				if err != nil {
+					return nil, err
+				}
+				d.Set("gateway_id", gatewayID)
+				d.Set("datasource_id", datasourceID)
 				d.SetId(parts[2])
 				return []*schema.ResourceData{d}, nil
 			},
@@ -73,7 +82,7 @@ func ResourceGatewayDatasourceUser() *schema.Resource {
 				Optional:    true,
 				ForceNew:    true,
 				Default:     "User",
-				Description: "Type of principal (User, Group, or App).",
+				Description: "Type of principal. Options: `User`, `Group`, `App`.",
 				ValidateFunc: validation.StringInSlice([]string{
 					"User", "Group", "App",
 				}, false),
@@ -82,7 +91,7 @@ func ResourceGatewayDatasourceUser() *schema.Resource {
 				Type:        schema.TypeString,
 				Required:    true,
 				ForceNew:    true,
-				Description: "Access right for the datasource.",
+				Description: "Access right for the datasource. Options: `Read`, `ReadOverrideEffectiveIdentity`.",
 				ValidateFunc: validation.StringInSlice([]string{
 					"Read", "ReadOverrideEffectiveIdentity",
 				}, false),
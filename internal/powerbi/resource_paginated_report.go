@@ -0,0 +1,351 @@
+package powerbi
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/codecutout/terraform-provider-powerbi/internal/powerbiapi"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// ResourcePaginatedReport represents a Power BI paginated report deployed from an RDL file
+func ResourcePaginatedReport() *schema.Resource {
+	return &schema.Resource{
+		Create: createPaginatedReport,
+		Read:   readPaginatedReport,
+		Update: updatePaginatedReport,
+		Delete: deletePaginatedReport,
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				client := meta.(*powerbiapi.Client)
+				idParts := strings.Split(d.Id(), "/")
+				if len(idParts) != 2 {
+					return nil, fmt.Errorf("invalid import ID, expected format: workspace_id/report_id or workspace_name/report_name")
+				}
+				groupID, err := resolveImportWorkspaceID(client, idParts[0])
+				if err != nil {
+					return nil, err
+				}
+				reportID, err := resolveImportReportID(client, groupID, idParts[1], "PaginatedReport")
+				if err != nil {
+					return nil, err
+				}
+				d.Set("workspace_id", groupID)
+				d.SetId(reportID)
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"workspace_id": {
+				Type:        schema.TypeString,
+				Description: "Workspace ID in which the paginated report will be added.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Name of the paginated report, without the `.rdl` extension.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"source": {
+				Type:        schema.TypeString,
+				Description: "An absolute path to an RDL file on the local system.",
+				Required:    true,
+			},
+			"source_hash": {
+				Type:        schema.TypeString,
+				Description: "Used to trigger updates. The only meaningful value is `${filemd5(\"path/to/file\")}`.",
+				Optional:    true,
+			},
+			"name_conflict": {
+				Type:         schema.TypeString,
+				Description:  "What to do if a paginated report with the same name already exists in the workspace when the resource is created. Any of: `Abort`, `Overwrite`. Updates always overwrite the existing report.",
+				Optional:     true,
+				Default:      "Abort",
+				ValidateFunc: validation.StringInSlice([]string{"Abort", "Overwrite"}, false),
+			},
+			"connection_string": {
+				Type:        schema.TypeSet,
+				Description: "Connection strings to replace in the RDL file before it is uploaded. Changing this value will require reuploading the RDL file.",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"datasource_name": {
+							Type:        schema.TypeString,
+							Description: "The name of the data source within the RDL file.",
+							Required:    true,
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Description: "The connection string to use for the data source.",
+							Required:    true,
+						},
+					},
+				},
+			},
+			"datasource": {
+				Type:        schema.TypeSet,
+				Description: "Datasources to be reconfigured after deploying the paginated report. These can be updated without requiring reuploading the RDL file. Any datasources not mentioned will not be tracked or updated.",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the data source within the paginated report.",
+							Required:    true,
+						},
+						"server": {
+							Type:        schema.TypeString,
+							Description: "The server name.",
+							Required:    true,
+						},
+						"database": {
+							Type:        schema.TypeString,
+							Description: "The database name.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"report_type": {
+				Type:        schema.TypeString,
+				Description: "The type of the report.",
+				Computed:    true,
+			},
+			"web_url": {
+				Type:        schema.TypeString,
+				Description: "Web URL of the paginated report.",
+				Computed:    true,
+			},
+			"embed_url": {
+				Type:        schema.TypeString,
+				Description: "Embed URL of the paginated report.",
+				Computed:    true,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func createPaginatedReport(d *schema.ResourceData, meta interface{}) error {
+
+	err := importPaginatedReport(d, meta, d.Get("name_conflict").(string), d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return err
+	}
+
+	err = setPaginatedReportDatasources(d, meta)
+	if err != nil {
+		return err
+	}
+
+	return readPaginatedReport(d, meta)
+}
+
+func readPaginatedReport(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+
+	report, err := client.GetReportInGroup(groupID, d.Id())
+	if powerbiapi.IsHTTP404Error(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	d.Set("name", report.Name)
+	d.Set("report_type", report.ReportType)
+	d.Set("web_url", report.WebURL)
+	d.Set("embed_url", report.EmbedURL)
+
+	return readPaginatedReportDatasources(d, meta)
+}
+
+func updatePaginatedReport(d *schema.ResourceData, meta interface{}) error {
+
+	// imports of paginated reports replace the datasources with those in the RDL file
+	// so datasources are always reapplied after a reupload
+	if d.HasChange("source") || d.HasChange("source_hash") || d.HasChange("connection_string") {
+		err := importPaginatedReport(d, meta, "Overwrite", d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return err
+		}
+
+		err = setPaginatedReportDatasources(d, meta)
+		if err != nil {
+			return err
+		}
+	} else if d.HasChange("datasource") {
+		err := setPaginatedReportDatasources(d, meta)
+		if err != nil {
+			return err
+		}
+	}
+
+	return readPaginatedReport(d, meta)
+}
+
+func deletePaginatedReport(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	err := client.DeleteReportInGroup(d.Get("workspace_id").(string), d.Id())
+	if powerbiapi.IsHTTP404Error(err) {
+		return nil
+	}
+	return err
+}
+
+func importPaginatedReport(d *schema.ResourceData, meta interface{}, nameConflict string, timeout time.Duration) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+
+	content, err := ioutil.ReadFile(d.Get("source").(string))
+	if err != nil {
+		return err
+	}
+
+	for _, connectionStringObj := range d.Get("connection_string").(*schema.Set).List() {
+		connectionStringObj := connectionStringObj.(map[string]interface{})
+		content, err = replaceRDLConnectString(content, connectionStringObj["datasource_name"].(string), connectionStringObj["value"].(string))
+		if err != nil {
+			return err
+		}
+	}
+
+	// the import API determines the file type from the extension of the display name
+	displayName := d.Get("name").(string) + ".rdl"
+
+	resp, err := client.PostImportInGroup(groupID, displayName, nameConflict, false, bytes.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("failed to import paginated report: %w", err)
+	}
+
+	im, err := client.WaitForImportInGroupToSucceed(groupID, resp.ID, timeout)
+	if err != nil {
+		return err
+	}
+	if len(im.Reports) < 1 {
+		return fmt.Errorf("import %s did not create a paginated report", resp.ID)
+	}
+
+	d.SetId(im.Reports[0].ID)
+
+	return nil
+}
+
+func setPaginatedReportDatasources(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	datasourceList := d.Get("datasource").(*schema.Set).List()
+	if len(datasourceList) == 0 {
+		return nil
+	}
+
+	request := powerbiapi.UpdateRDLDatasourcesInGroupRequest{}
+	for _, datasourceObj := range datasourceList {
+		datasourceObj := datasourceObj.(map[string]interface{})
+		request.UpdateDetails = append(request.UpdateDetails, powerbiapi.UpdateRDLDatasourcesInGroupRequestItem{
+			DatasourceName: datasourceObj["name"].(string),
+			ConnectionDetails: powerbiapi.UpdateRDLDatasourcesInGroupRequestItemConnectionDetails{
+				Server:   datasourceObj["server"].(string),
+				Database: datasourceObj["database"].(string),
+			},
+		})
+	}
+
+	err := client.UpdateDatasourcesOfReportInGroup(d.Get("workspace_id").(string), d.Id(), request)
+	if err != nil {
+		return fmt.Errorf("failed to update datasources of paginated report %s: %w", d.Id(), err)
+	}
+	return nil
+}
+
+func readPaginatedReportDatasources(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	stateDatasources := d.Get("datasource").(*schema.Set)
+	if stateDatasources.Len() == 0 {
+		return nil
+	}
+
+	apiDatasources, err := client.GetDatasourcesOfReportInGroup(d.Get("workspace_id").(string), d.Id())
+	if err != nil {
+		return err
+	}
+
+	// only datasources that are configured are tracked, datasources that no
+	// longer exist in the report are removed so they will be set again
+	datasourceList := make([]interface{}, 0, stateDatasources.Len())
+	for _, stateDatasource := range stateDatasources.List() {
+		stateDatasourceObj := stateDatasource.(map[string]interface{})
+		for _, apiDatasource := range apiDatasources.Value {
+			if apiDatasource.Name == stateDatasourceObj["name"] {
+				datasourceList = append(datasourceList, map[string]interface{}{
+					"name":     apiDatasource.Name,
+					"server":   nilToEmptyString(apiDatasource.ConnectionDetails.Server),
+					"database": nilToEmptyString(apiDatasource.ConnectionDetails.Database),
+				})
+			}
+		}
+	}
+
+	d.Set("datasource", datasourceList)
+	return nil
+}
+
+var rdlDataSourceRegexp = regexp.MustCompile(`(?s)<DataSource\s[^>]*Name="([^"]*)"[^>]*>.*?</DataSource>`)
+var rdlConnectStringRegexp = regexp.MustCompile(`(?s)<ConnectString>.*?</ConnectString>`)
+
+// replaceRDLConnectString replaces the connection string of the named data source within the RDL content
+func replaceRDLConnectString(content []byte, datasourceName string, connectString string) ([]byte, error) {
+	var escaped bytes.Buffer
+	if err := xml.EscapeText(&escaped, []byte(connectString)); err != nil {
+		return nil, err
+	}
+	replacement := []byte("<ConnectString>" + escaped.String() + "</ConnectString>")
+
+	found := false
+	var replaceErr error
+	result := rdlDataSourceRegexp.ReplaceAllFunc(content, func(dataSource []byte) []byte {
+		name := rdlDataSourceRegexp.FindSubmatch(dataSource)[1]
+		if unescapeXMLAttribute(string(name)) != datasourceName {
+			return dataSource
+		}
+		found = true
+		if !rdlConnectStringRegexp.Match(dataSource) {
+			replaceErr = fmt.Errorf("data source %s in RDL file does not have a connection string", datasourceName)
+			return dataSource
+		}
+		return rdlConnectStringRegexp.ReplaceAllLiteral(dataSource, replacement)
+	})
+
+	if replaceErr != nil {
+		return nil, replaceErr
+	}
+	if !found {
+		return nil, fmt.Errorf("data source %s not found in RDL file", datasourceName)
+	}
+	return result, nil
+}
+
+func unescapeXMLAttribute(value string) string {
+	var unescaped string
+	if err := xml.Unmarshal([]byte("<v>"+value+"</v>"), &unescaped); err != nil {
+		return value
+	}
+	return unescaped
+}
@@ -0,0 +1,152 @@
+package powerbi
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccPaginatedReport_basic(t *testing.T) {
+	var reportID string
+	workspaceSuffix := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerbiWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			// first step uploads the RDL file with a replaced connection string
+			{
+				Config: testAccPaginatedReportConfig(workspaceSuffix, "first.database.windows.net", "first"),
+				Check: resource.ComposeTestCheckFunc(
+					set("powerbi_paginated_report.test", "id", &reportID),
+					resource.TestCheckResourceAttr("powerbi_paginated_report.test", "name", "Acceptance Test Paginated Report"),
+					resource.TestCheckResourceAttr("powerbi_paginated_report.test", "report_type", "PaginatedReport"),
+					resource.TestCheckResourceAttrSet("powerbi_paginated_report.test", "web_url"),
+				),
+			},
+			// changing the datasource updates the report in place
+			{
+				Config: testAccPaginatedReportConfig(workspaceSuffix, "second.database.windows.net", "first"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckResourceAttrUnchanged("powerbi_paginated_report.test", "id", &reportID),
+					resource.TestCheckResourceAttr("powerbi_paginated_report.test", "datasource.#", "1"),
+				),
+			},
+			// changing the connection string reuploads the RDL file
+			{
+				Config: testAccPaginatedReportConfig(workspaceSuffix, "second.database.windows.net", "second"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_paginated_report.test", "datasource.#", "1"),
+				),
+			},
+			// final step checks importing the current state we reached in the previous step
+			{
+				ResourceName:      "powerbi_paginated_report.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccPaginatedReportImportStateIdFunc("powerbi_paginated_report.test"),
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"source", "source_hash", "name_conflict", "connection_string", "datasource",
+				},
+			},
+		},
+	})
+}
+
+func TestReplaceRDLConnectString(t *testing.T) {
+	content := []byte(`<Report>
+  <DataSources>
+    <DataSource Name="First">
+      <ConnectionProperties>
+        <ConnectString>Data Source=first</ConnectString>
+      </ConnectionProperties>
+    </DataSource>
+    <DataSource Name="Second &amp; Third">
+      <ConnectionProperties>
+        <ConnectString>Data Source=second</ConnectString>
+      </ConnectionProperties>
+    </DataSource>
+  </DataSources>
+</Report>`)
+
+	result, err := replaceRDLConnectString(content, "Second & Third", "Data Source=new;Initial Catalog=<db>")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `<Report>
+  <DataSources>
+    <DataSource Name="First">
+      <ConnectionProperties>
+        <ConnectString>Data Source=first</ConnectString>
+      </ConnectionProperties>
+    </DataSource>
+    <DataSource Name="Second &amp; Third">
+      <ConnectionProperties>
+        <ConnectString>Data Source=new;Initial Catalog=&lt;db&gt;</ConnectString>
+      </ConnectionProperties>
+    </DataSource>
+  </DataSources>
+</Report>`
+	if string(result) != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, string(result))
+	}
+}
+
+func TestReplaceRDLConnectString_errors(t *testing.T) {
+	testCases := []struct {
+		name           string
+		content        string
+		datasourceName string
+	}{
+		{"missing data source", `<DataSource Name="First"><ConnectString>a</ConnectString></DataSource>`, "Second"},
+		{"missing connection string", `<DataSource Name="First"><DataSourceReference>shared</DataSourceReference></DataSource>`, "First"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := replaceRDLConnectString([]byte(tc.content), tc.datasourceName, "b")
+			if err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+}
+
+func testAccPaginatedReportImportStateIdFunc(name string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return "", fmt.Errorf("not found: %s", name)
+		}
+		return fmt.Sprintf("%s/%s", rs.Primary.Attributes["workspace_id"], rs.Primary.ID), nil
+	}
+}
+
+func testAccPaginatedReportConfig(workspaceSuffix string, server string, database string) string {
+	return fmt.Sprintf(`
+	resource "powerbi_workspace" "test" {
+		name = "Acceptance Test Workspace %s"
+	}
+
+	resource "powerbi_paginated_report" "test" {
+		workspace_id = "${powerbi_workspace.test.id}"
+		name = "Acceptance Test Paginated Report"
+		source = "./resource_paginated_report_test_sample.rdl"
+		source_hash = "${filemd5("./resource_paginated_report_test_sample.rdl")}"
+		connection_string {
+			datasource_name = "SampleDataSource"
+			value = "Data Source=original.database.windows.net;Initial Catalog=%s"
+		}
+		datasource {
+			name = "SampleDataSource"
+			server = "%s"
+			database = "%s"
+		}
+	}
+	`, workspaceSuffix, database, server, database)
+}
@@ -0,0 +1,494 @@
+package powerbi
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codecutout/terraform-provider-powerbi/internal/powerbiapi"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// ResourcePBIP represents a Power BI Project (PBIP) folder deployed as a semantic model and report
+func ResourcePBIP() *schema.Resource {
+	return &schema.Resource{
+		Create: createPBIP,
+		Read:   readPBIP,
+		Update: updatePBIP,
+		Delete: deletePBIP,
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				client := meta.(*powerbiapi.Client)
+				idParts := strings.Split(d.Id(), "/")
+
+				// a single name refers to a semantic model and report with the same name
+				if len(idParts) == 2 {
+					idParts = append(idParts, idParts[1])
+				}
+				if len(idParts) != 3 || (idParts[1] == "" && idParts[2] == "") {
+					return nil, fmt.Errorf("invalid import ID, expected format: workspace_id/dataset_id/report_id, either ID may be empty, or workspace_name/name")
+				}
+
+				groupID, err := resolveImportWorkspaceID(client, idParts[0])
+				if err != nil {
+					return nil, err
+				}
+				datasetID, reportID := "", ""
+				if idParts[1] != "" {
+					if datasetID, err = resolveImportDatasetID(client, groupID, idParts[1]); err != nil {
+						return nil, err
+					}
+				}
+				if idParts[2] != "" {
+					if reportID, err = resolveImportReportID(client, groupID, idParts[2], ""); err != nil {
+						return nil, err
+					}
+				}
+				d.Set("workspace_id", groupID)
+				d.Set("dataset_id", datasetID)
+				d.Set("report_id", reportID)
+				d.SetId(firstNonEmptyString(reportID, datasetID))
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+		CustomizeDiff: customizePBIPSourceSHA256Diff,
+
+		Schema: map[string]*schema.Schema{
+			"workspace_id": {
+				Type:        schema.TypeString,
+				Description: "Workspace ID in which the PBIP will be deployed.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Name of the semantic model and report.",
+				Required:    true,
+			},
+			"source": {
+				Type:        schema.TypeString,
+				Description: "An absolute path to a Power BI Project folder, containing a `.SemanticModel` folder, a `.Report` folder or both. Reports that refer to the semantic model by path are connected to the deployed semantic model. Adding or removing the semantic model or report from the folder will recreate the resource.",
+				Required:    true,
+			},
+			"source_sha256": {
+				Type:        schema.TypeString,
+				Description: "The SHA-256 of the files within the project folder at `source`, calculated when planning. Local settings and caches in `.pbi` folders are ignored. Any change to the files will update the semantic model and report definitions.",
+				Computed:    true,
+			},
+			"dataset_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the semantic model deployed from the project. Empty if the project does not contain a semantic model.",
+				Computed:    true,
+			},
+			"report_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the report deployed from the project. Empty if the project does not contain a report.",
+				Computed:    true,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(10 * time.Minute),
+		},
+	}
+}
+
+// pbipProject represents the item folders found within a Power BI Project folder
+type pbipProject struct {
+	SemanticModelFolder string
+	ReportFolder        string
+}
+
+func createPBIP(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+	name := d.Get("name").(string)
+	timeout := d.Timeout(schema.TimeoutCreate)
+
+	project, err := findPBIPProject(d.Get("source").(string))
+	if err != nil {
+		return err
+	}
+
+	// the semantic model is created first so the report can connect to it
+	d.Partial(true)
+	if project.SemanticModelFolder != "" {
+		definition, err := readPBIPItemDefinition(project.SemanticModelFolder)
+		if err != nil {
+			return err
+		}
+
+		item, err := client.CreateItem(groupID, powerbiapi.CreateItemRequest{
+			DisplayName: name,
+			Type:        "SemanticModel",
+			Definition:  definition,
+		}, timeout)
+		if err != nil {
+			return fmt.Errorf("failed to create semantic model: %w", err)
+		}
+		d.SetId(item.ID)
+		d.Set("dataset_id", item.ID)
+		d.SetPartial("dataset_id")
+	}
+
+	if project.ReportFolder != "" {
+		definition, err := readPBIPReportDefinition(project.ReportFolder, d.Get("dataset_id").(string))
+		if err != nil {
+			return err
+		}
+
+		item, err := client.CreateItem(groupID, powerbiapi.CreateItemRequest{
+			DisplayName: name,
+			Type:        "Report",
+			Definition:  definition,
+		}, timeout)
+		if err != nil {
+			return fmt.Errorf("failed to create report: %w", err)
+		}
+		d.SetId(item.ID)
+		d.Set("report_id", item.ID)
+	}
+	d.Partial(false)
+
+	return readPBIP(d, meta)
+}
+
+func readPBIP(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+
+	// either item being deleted outside of terraform means the project must be deployed again
+	names := []string{}
+	for _, key := range []string{"dataset_id", "report_id"} {
+		itemID := d.Get(key).(string)
+		if itemID == "" {
+			continue
+		}
+
+		item, err := client.GetItem(groupID, itemID)
+		if powerbiapi.IsHTTP404Error(err) {
+			d.SetId("")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if len(names) == 0 || names[0] != item.DisplayName {
+			names = append(names, item.DisplayName)
+		}
+	}
+
+	// when the items have been given different names outside of terraform both names are
+	// shown, so the name never matches the configuration and the next apply renames both
+	if len(names) > 0 {
+		d.Set("name", strings.Join(names, " / "))
+	}
+	return nil
+}
+
+func updatePBIP(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+	timeout := d.Timeout(schema.TimeoutUpdate)
+
+	if d.HasChange("source") || d.HasChange("source_sha256") {
+		project, err := findPBIPProject(d.Get("source").(string))
+		if err != nil {
+			return err
+		}
+
+		if (project.SemanticModelFolder != "") != (d.Get("dataset_id").(string) != "") ||
+			(project.ReportFolder != "") != (d.Get("report_id").(string) != "") {
+			return fmt.Errorf("the items within %s no longer match the deployed items, the resource must be recreated", d.Get("source"))
+		}
+
+		if project.SemanticModelFolder != "" {
+			definition, err := readPBIPItemDefinition(project.SemanticModelFolder)
+			if err != nil {
+				return err
+			}
+			err = client.UpdateItemDefinition(groupID, d.Get("dataset_id").(string), powerbiapi.UpdateItemDefinitionRequest{Definition: *definition}, timeout)
+			if err != nil {
+				return fmt.Errorf("failed to update semantic model definition: %w", err)
+			}
+		}
+
+		if project.ReportFolder != "" {
+			definition, err := readPBIPReportDefinition(project.ReportFolder, d.Get("dataset_id").(string))
+			if err != nil {
+				return err
+			}
+			err = client.UpdateItemDefinition(groupID, d.Get("report_id").(string), powerbiapi.UpdateItemDefinitionRequest{Definition: *definition}, timeout)
+			if err != nil {
+				return fmt.Errorf("failed to update report definition: %w", err)
+			}
+		}
+	}
+
+	if d.HasChange("name") {
+		for _, key := range []string{"dataset_id", "report_id"} {
+			if itemID := d.Get(key).(string); itemID != "" {
+				err := client.UpdateItem(groupID, itemID, powerbiapi.UpdateItemRequest{DisplayName: d.Get("name").(string)})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return readPBIP(d, meta)
+}
+
+func deletePBIP(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+
+	// the report is deleted first as it depends on the semantic model
+	for _, key := range []string{"report_id", "dataset_id"} {
+		if itemID := d.Get(key).(string); itemID != "" {
+			err := client.DeleteItem(groupID, itemID)
+			if err != nil && !powerbiapi.IsHTTP404Error(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func customizePBIPSourceSHA256Diff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("source") {
+		return d.SetNewComputed("source_sha256")
+	}
+
+	source := d.Get("source").(string)
+	if _, err := os.Stat(source); os.IsNotExist(err) {
+		return d.SetNewComputed("source_sha256")
+	}
+
+	hash, err := hashPBIPFolder(source)
+	if err != nil {
+		return fmt.Errorf("failed to calculate hash of source: %w", err)
+	}
+	if hash != d.Get("source_sha256").(string) {
+		if err := d.SetNew("source_sha256", hash); err != nil {
+			return err
+		}
+	}
+
+	// adding or removing items cannot be done by updating definitions, whether or not the source path changed
+	if d.Id() != "" {
+		project, err := findPBIPProject(source)
+		if err != nil {
+			return err
+		}
+		if (project.SemanticModelFolder != "") != (d.Get("dataset_id").(string) != "") ||
+			(project.ReportFolder != "") != (d.Get("report_id").(string) != "") {
+			forceNewKey := "source_sha256"
+			if d.HasChange("source") {
+				forceNewKey = "source"
+			}
+			if err := d.ForceNew(forceNewKey); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// findPBIPProject finds the semantic model and report folders within a project folder
+func findPBIPProject(source string) (*pbipProject, error) {
+	entries, err := ioutil.ReadDir(source)
+	if err != nil {
+		return nil, err
+	}
+
+	project := pbipProject{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		var folder *string
+		if strings.HasSuffix(entry.Name(), ".SemanticModel") || strings.HasSuffix(entry.Name(), ".Dataset") {
+			folder = &project.SemanticModelFolder
+		} else if strings.HasSuffix(entry.Name(), ".Report") {
+			folder = &project.ReportFolder
+		} else {
+			continue
+		}
+
+		if *folder != "" {
+			return nil, fmt.Errorf("%s contains more than one %s folder, only one semantic model and report can be deployed", source, filepath.Ext(entry.Name()))
+		}
+		*folder = filepath.Join(source, entry.Name())
+	}
+
+	if project.SemanticModelFolder == "" && project.ReportFolder == "" {
+		return nil, fmt.Errorf("%s does not contain a .SemanticModel or .Report folder", source)
+	}
+	return &project, nil
+}
+
+// listPBIPFiles returns the paths of files relative to an item folder using forward slashes.
+// The .pbi folders hold local settings and caches, and .platform holds the identity of the
+// item in source control, neither are part of the item definition
+func listPBIPFiles(folder string) ([]string, error) {
+	files := []string{}
+	err := filepath.Walk(folder, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && info.Name() == ".pbi" {
+			return filepath.SkipDir
+		}
+		if info.IsDir() || info.Name() == ".platform" {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(folder, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(relativePath))
+		return nil
+	})
+	sort.Strings(files)
+	return files, err
+}
+
+func readPBIPItemDefinition(folder string) (*powerbiapi.ItemDefinition, error) {
+	files, err := listPBIPFiles(folder)
+	if err != nil {
+		return nil, err
+	}
+
+	definition := powerbiapi.ItemDefinition{Parts: []powerbiapi.ItemDefinitionPart{}}
+	for _, file := range files {
+		content, err := ioutil.ReadFile(filepath.Join(folder, filepath.FromSlash(file)))
+		if err != nil {
+			return nil, err
+		}
+		definition.Parts = append(definition.Parts, powerbiapi.ItemDefinitionPart{
+			Path:        file,
+			Payload:     base64.StdEncoding.EncodeToString(content),
+			PayloadType: "InlineBase64",
+		})
+	}
+	return &definition, nil
+}
+
+// readPBIPReportDefinition reads a report definition, connecting reports that refer
+// to a semantic model by path to the semantic model deployed from the project
+func readPBIPReportDefinition(folder string, datasetID string) (*powerbiapi.ItemDefinition, error) {
+	definition, err := readPBIPItemDefinition(folder)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, part := range definition.Parts {
+		if part.Path != "definition.pbir" {
+			continue
+		}
+
+		content, err := base64.StdEncoding.DecodeString(part.Payload)
+		if err != nil {
+			return nil, err
+		}
+		content, err = setPBIRDatasetReference(content, datasetID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid definition.pbir in %s: %w", folder, err)
+		}
+		definition.Parts[i].Payload = base64.StdEncoding.EncodeToString(content)
+	}
+	return definition, nil
+}
+
+// setPBIRDatasetReference replaces a by path dataset reference with a connection to the deployed semantic model
+func setPBIRDatasetReference(content []byte, datasetID string) ([]byte, error) {
+	var pbir map[string]interface{}
+	if err := json.Unmarshal(content, &pbir); err != nil {
+		return nil, err
+	}
+
+	datasetReference, _ := pbir["datasetReference"].(map[string]interface{})
+	if datasetReference == nil || datasetReference["byPath"] == nil {
+		return content, nil
+	}
+	if datasetID == "" {
+		return nil, fmt.Errorf("report refers to a semantic model by path but the project does not contain a semantic model")
+	}
+
+	// version 4 of the format only needs the semantic model ID, earlier versions need the full connection
+	version, _ := pbir["version"].(string)
+	majorVersion, _ := strconv.Atoi(strings.SplitN(version, ".", 2)[0])
+	if majorVersion >= 4 {
+		pbir["datasetReference"] = map[string]interface{}{
+			"byConnection": map[string]interface{}{
+				"connectionString": "semanticmodelid=" + datasetID,
+			},
+		}
+	} else {
+		pbir["datasetReference"] = map[string]interface{}{
+			"byPath": nil,
+			"byConnection": map[string]interface{}{
+				"connectionString":          nil,
+				"pbiServiceModelId":         nil,
+				"pbiModelVirtualServerName": "sobe_wowvirtualserver",
+				"pbiModelDatabaseName":      datasetID,
+				"name":                      "EntityDataSource",
+				"connectionType":            "pbiServiceXmlaStyleLive",
+			},
+		}
+	}
+	return json.MarshalIndent(pbir, "", "  ")
+}
+
+// hashPBIPFolder returns the SHA-256 of the names and contents of the item definition files within a project folder
+func hashPBIPFolder(source string) (string, error) {
+	project, err := findPBIPProject(source)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	for _, folder := range []string{project.SemanticModelFolder, project.ReportFolder} {
+		if folder == "" {
+			continue
+		}
+
+		files, err := listPBIPFiles(folder)
+		if err != nil {
+			return "", err
+		}
+		for _, file := range files {
+			content, err := ioutil.ReadFile(filepath.Join(folder, filepath.FromSlash(file)))
+			if err != nil {
+				return "", err
+			}
+			fileHash := sha256.Sum256(content)
+			fmt.Fprintf(hash, "%s/%s\x00%x\n", filepath.Base(folder), file, fileHash)
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func firstNonEmptyString(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,296 @@
+package powerbi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/codecutout/terraform-provider-powerbi/internal/powerbiapi"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+const samplePBIP = "./resource_pbip_test_sample"
+
+func TestAccPBIP_basic(t *testing.T) {
+	pbipLocation := filepath.Join(t.TempDir(), "Sample")
+	pbipLocationTfFriendly := strings.ReplaceAll(pbipLocation, "\\", "\\\\")
+	workspaceSuffix := acctest.RandString(6)
+	var datasetID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerbiWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			// first step creates the semantic model and report
+			{
+				PreConfig: func() {
+					copyTestDir(t, samplePBIP, pbipLocation)
+				},
+				Config: testAccPBIPConfig(workspaceSuffix, "Acceptance Test PBIP", pbipLocationTfFriendly),
+				Check: resource.ComposeTestCheckFunc(
+					set("powerbi_pbip.test", "dataset_id", &datasetID),
+					testCheckDatasetExistsInWorkspace("powerbi_workspace.test", "Acceptance Test PBIP"),
+					testCheckReportExistsInWorkspace("powerbi_workspace.test", "Acceptance Test PBIP"),
+					resource.TestCheckResourceAttrSet("powerbi_pbip.test", "report_id"),
+					resource.TestCheckResourceAttrSet("powerbi_pbip.test", "source_sha256"),
+				),
+			},
+			// changing the model updates the definitions in place
+			{
+				PreConfig: func() {
+					modelFile := filepath.Join(pbipLocation, "Sample.SemanticModel", "model.bim")
+					content, _ := ioutil.ReadFile(modelFile)
+					ioutil.WriteFile(modelFile, []byte(strings.ReplaceAll(string(content), "Bob", "Carol")), 0644)
+				},
+				Config: testAccPBIPConfig(workspaceSuffix, "Acceptance Test PBIP Renamed", pbipLocationTfFriendly),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("powerbi_pbip.test", "dataset_id", &datasetID),
+					testCheckDatasetExistsInWorkspace("powerbi_workspace.test", "Acceptance Test PBIP Renamed"),
+					testCheckReportExistsInWorkspace("powerbi_workspace.test", "Acceptance Test PBIP Renamed"),
+				),
+			},
+			{
+				ResourceName:            "powerbi_pbip.test",
+				ImportState:             true,
+				ImportStateIdFunc:       testAccPBIPImportStateIdFunc("powerbi_pbip.test"),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"source", "source_sha256"},
+			},
+			// final step checks that all resources are removed
+			{
+				Config: fmt.Sprintf(`
+				resource "powerbi_workspace" "test" {
+					name = "Acceptance Test Workspace %s"
+				}
+				`, workspaceSuffix),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckResourceRemoved("powerbi_pbip.test"),
+					testCheckDatasetDoesNotExistsInWorkspace("powerbi_workspace.test", "Acceptance Test PBIP Renamed"),
+					testCheckReportDoesNotExistsInWorkspace("powerbi_workspace.test", "Acceptance Test PBIP Renamed"),
+				),
+			},
+		},
+	})
+}
+
+func TestReadPBIP_names(t *testing.T) {
+	tests := map[string]struct {
+		reportName string
+		expected   string
+	}{
+		"same name":      {reportName: "Sales", expected: "Sales"},
+		"report renamed": {reportName: "Sales (renamed)", expected: "Sales / Sales (renamed)"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &powerbiapi.Client{Client: &http.Client{Transport: testPowerbiRoundTripper{
+				"/v1/workspaces/workspace/items/dataset": `{"id":"dataset","displayName":"Sales"}`,
+				"/v1/workspaces/workspace/items/report":  fmt.Sprintf(`{"id":"report","displayName":%q}`, tt.reportName),
+			}}}
+			d := schema.TestResourceDataRaw(t, ResourcePBIP().Schema, map[string]interface{}{
+				"workspace_id": "workspace",
+				"name":         "Sales",
+			})
+			d.SetId("report")
+			d.Set("dataset_id", "dataset")
+			d.Set("report_id", "report")
+
+			if err := readPBIP(d, client); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual := d.Get("name").(string); actual != tt.expected {
+				t.Errorf("expected name %q, got %q", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestFindPBIPProject(t *testing.T) {
+	project, err := findPBIPProject(samplePBIP)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := &pbipProject{
+		SemanticModelFolder: filepath.Join(samplePBIP, "Sample.SemanticModel"),
+		ReportFolder:        filepath.Join(samplePBIP, "Sample.Report"),
+	}
+	if !reflect.DeepEqual(project, expected) {
+		t.Errorf("expected %+v, got %+v", expected, project)
+	}
+}
+
+func TestFindPBIPProject_errors(t *testing.T) {
+	empty := t.TempDir()
+
+	multiple := t.TempDir()
+	os.Mkdir(filepath.Join(multiple, "One.Report"), 0755)
+	os.Mkdir(filepath.Join(multiple, "Two.Report"), 0755)
+
+	for name, source := range map[string]string{"missing": filepath.Join(empty, "missing"), "empty": empty, "multiple": multiple} {
+		if _, err := findPBIPProject(source); err == nil {
+			t.Errorf("expected error for %s project", name)
+		}
+	}
+}
+
+func TestHashPBIPFolder(t *testing.T) {
+	pbipLocation := filepath.Join(t.TempDir(), "Sample")
+	copyTestDir(t, samplePBIP, pbipLocation)
+
+	originalHash, err := hashPBIPFolder(pbipLocation)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// local settings and item identity are not part of the definition
+	ioutil.WriteFile(filepath.Join(pbipLocation, "Sample.SemanticModel", ".pbi", "editorSettings.json"), []byte("{}"), 0644)
+	ioutil.WriteFile(filepath.Join(pbipLocation, "Sample.Report", ".platform"), []byte("{}"), 0644)
+	hash, _ := hashPBIPFolder(pbipLocation)
+	if hash != originalHash {
+		t.Errorf("expected hash to ignore local settings")
+	}
+
+	ioutil.WriteFile(filepath.Join(pbipLocation, "Sample.Report", "report.json"), []byte("{}"), 0644)
+	hash, _ = hashPBIPFolder(pbipLocation)
+	if hash == originalHash {
+		t.Errorf("expected hash to change when report changes")
+	}
+}
+
+func TestReadPBIPItemDefinition(t *testing.T) {
+	definition, err := readPBIPItemDefinition(filepath.Join(samplePBIP, "Sample.SemanticModel"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	paths := []string{}
+	for _, part := range definition.Parts {
+		paths = append(paths, part.Path)
+		if part.PayloadType != "InlineBase64" {
+			t.Errorf("expected inline base64 payload for %s, got %s", part.Path, part.PayloadType)
+		}
+	}
+	expectedPaths := []string{"definition.pbism", "model.bim"}
+	if !reflect.DeepEqual(paths, expectedPaths) {
+		t.Errorf("expected parts %v, got %v", expectedPaths, paths)
+	}
+}
+
+func TestReadPBIPReportDefinition(t *testing.T) {
+	definition, err := readPBIPReportDefinition(filepath.Join(samplePBIP, "Sample.Report"), "b2c1a0f8-0000-0000-0000-000000000000")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var pbir struct {
+		DatasetReference map[string]map[string]interface{}
+	}
+	for _, part := range definition.Parts {
+		if part.Path == "definition.pbir" {
+			content, _ := base64.StdEncoding.DecodeString(part.Payload)
+			if err := json.Unmarshal(content, &pbir); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+	}
+
+	if pbir.DatasetReference["byPath"] != nil {
+		t.Errorf("expected path reference to be removed, got %v", pbir.DatasetReference["byPath"])
+	}
+	connection := pbir.DatasetReference["byConnection"]
+	if connection["pbiModelDatabaseName"] != "b2c1a0f8-0000-0000-0000-000000000000" || connection["connectionType"] != "pbiServiceXmlaStyleLive" {
+		t.Errorf("expected connection to deployed semantic model, got %v", connection)
+	}
+
+	if _, err := readPBIPReportDefinition(filepath.Join(samplePBIP, "Sample.Report"), ""); err == nil {
+		t.Errorf("expected error when project has no semantic model")
+	}
+}
+
+func TestSetPBIRDatasetReference(t *testing.T) {
+	tests := map[string]struct {
+		pbir     string
+		expected string
+	}{
+		"version 4": {
+			pbir:     `{"version":"4.0","datasetReference":{"byPath":{"path":"../Sample.SemanticModel"}}}`,
+			expected: `{"datasetReference":{"byConnection":{"connectionString":"semanticmodelid=abc"}},"version":"4.0"}`,
+		},
+		"connection unchanged": {
+			pbir:     `{"version":"4.0","datasetReference":{"byConnection":{"connectionString":"semanticmodelid=other"}}}`,
+			expected: `{"datasetReference":{"byConnection":{"connectionString":"semanticmodelid=other"}},"version":"4.0"}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			content, err := setPBIRDatasetReference([]byte(test.pbir), "abc")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var actual, expected interface{}
+			json.Unmarshal(content, &actual)
+			json.Unmarshal([]byte(test.expected), &expected)
+			if !reflect.DeepEqual(actual, expected) {
+				t.Errorf("expected %s, got %s", test.expected, content)
+			}
+		})
+	}
+}
+
+func testAccPBIPImportStateIdFunc(name string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return "", fmt.Errorf("not found: %s", name)
+		}
+		return fmt.Sprintf("%s/%s/%s", rs.Primary.Attributes["workspace_id"], rs.Primary.Attributes["dataset_id"], rs.Primary.Attributes["report_id"]), nil
+	}
+}
+
+func testAccPBIPConfig(workspaceSuffix string, name string, source string) string {
+	return fmt.Sprintf(`
+	resource "powerbi_workspace" "test" {
+		name = "Acceptance Test Workspace %s"
+	}
+
+	resource "powerbi_pbip" "test" {
+		workspace_id = "${powerbi_workspace.test.id}"
+		name = "%s"
+		source = "%s"
+	}
+	`, workspaceSuffix, name, source)
+}
+
+func copyTestDir(t *testing.T, src string, dst string) {
+	err := filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relativePath, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return os.MkdirAll(filepath.Join(dst, relativePath), 0755)
+		}
+		return Copy(path, filepath.Join(dst, relativePath))
+	})
+	if err != nil {
+		t.Fatalf("failed to copy %s: %v", src, err)
+	}
+}
@@ -1,13 +1,24 @@
 package powerbi
 
 import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"log"
 	"os"
+	"reflect"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/codecutout/terraform-provider-powerbi/internal/pbixrewriter"
 	"github.com/codecutout/terraform-provider-powerbi/internal/powerbiapi"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 )
 
 // ResourcePBIX represents a Power BI PBIX file
@@ -18,15 +29,52 @@ func ResourcePBIX() *schema.Resource {
 		Update: updatePBIX,
 		Delete: deletePBIX,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				// a single ID is an import in My workspace
+				idParts := strings.Split(d.Id(), "/")
+				if len(idParts) == 1 {
+					return []*schema.ResourceData{d}, nil
+				}
+				if len(idParts) != 2 {
+					return nil, fmt.Errorf("invalid import ID, expected format: import_id, workspace_id/import_id or workspace_name/pbix_name")
+				}
+
+				client := meta.(*powerbiapi.Client)
+				groupID, err := resolveImportWorkspaceID(client, idParts[0])
+				if err != nil {
+					return nil, err
+				}
+				importID, err := resolveImportPBIXImportID(client, groupID, idParts[1])
+				if err != nil {
+					return nil, err
+				}
+				d.Set("workspace_id", groupID)
+				d.SetId(importID)
+				return []*schema.ResourceData{d}, nil
+			},
 		},
+		CustomizeDiff: customdiff.All(
+			customizePBIXSourceSHA256Diff,
+			customizePBIXFileSHA256Diff("strip_data_template", "strip_data_template_sha256"),
+			customizePBIXFileSHA256Diff("theme_file", "theme_sha256"),
+			customizePBIXContentSummaryDiff,
+			customizePBIXNameConflictDiff,
+		),
 
 		Schema: map[string]*schema.Schema{
 			"workspace_id": {
-				Type:        schema.TypeString,
-				Description: "Workspace ID in which the PBIX will be added.",
-				Required:    true,
-				ForceNew:    true,
+				Type:         schema.TypeString,
+				Description:  "Workspace ID in which the PBIX will be added.",
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"workspace_id", "my_workspace"},
+			},
+			"my_workspace": {
+				Type:         schema.TypeBool,
+				Description:  "If true, the PBIX will be added to \"My workspace\" of the authenticated user instead of a workspace. Not supported when authenticating as a service principal.",
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"workspace_id", "my_workspace"},
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -41,8 +89,40 @@ func ResourcePBIX() *schema.Resource {
 			},
 			"source_hash": {
 				Type:        schema.TypeString,
-				Description: "Used to trigger updates. The only meaningful value is `${filemd5(\"path/to/file\")}`.",
+				Description: "Used to trigger updates. The only meaningful value is `${filemd5(\"path/to/file\")}`. Changes to the file are also detected through `source_sha256`, so this is only needed to force an upload.",
+				Optional:    true,
+			},
+			"normalize_source_hash": {
+				Type:        schema.TypeBool,
+				Description: "If true, `source_sha256` is calculated from the contents of the PBIX ignoring zip timestamps and the `SecurityBindings` entry, so re-saving a PBIX without changes does not trigger an upload. Changing this value does not trigger an upload unless the PBIX has also changed.",
+				Optional:    true,
+				Default:     false,
+			},
+			"source_sha256": {
+				Type:        schema.TypeString,
+				Description: "The SHA-256 of the PBIX file at `source`, calculated when planning. Any change to the file will trigger an upload, even without setting `source_hash`.",
+				Computed:    true,
+			},
+			"content_summary": {
+				Type:        schema.TypeMap,
+				Description: "A summary of the pages, visuals, measures, Power Query queries and connections within the PBIX file at `source`, calculated when planning so that changes to the content of the PBIX are shown in the plan. Measures are only included for PBIX files without imported data. Long expressions are shortened.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"name_conflict": {
+				Type:         schema.TypeString,
+				Description:  "What to do if a dataset or report with the same name already exists when the PBIX is first uploaded. Any of: `Abort`, `Overwrite`, `CreateOrOverwrite`, `GenerateUniqueName`, `Ignore`. Later uploads overwrite the dataset and report created by this resource, except for `GenerateUniqueName` and `Ignore` which replace the resource as the name cannot identify what to overwrite.",
+				Optional:     true,
+				Default:      "CreateOrOverwrite",
+				ValidateFunc: validation.StringInSlice([]string{"Abort", "Overwrite", "CreateOrOverwrite", "GenerateUniqueName", "Ignore"}, false),
+			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Description: "If true and the PBIX has previously been uploaded with the same name, the existing dataset and report are brought under management when the resource is created instead of uploading the PBIX again. Parameters, datasources and rebinding are still applied.",
 				Optional:    true,
+				Default:     false,
 			},
 			"skip_report": {
 				Type:        schema.TypeBool,
@@ -50,6 +130,59 @@ func ResourcePBIX() *schema.Resource {
 				Optional:    true,
 				Default:     false,
 			},
+			"strip_data": {
+				Type:         schema.TypeBool,
+				Description:  "If true, the imported data cached in the PBIX is removed before uploading by replacing the model with the model definition from `strip_data_template`. The dataset is empty after each upload and must be refreshed before it can be used, consider setting `refresh_after_deploy`. Changing this value will require reuploading the PBIX.",
+				Optional:     true,
+				Default:      false,
+				RequiredWith: []string{"strip_data_template"},
+			},
+			"strip_data_template": {
+				Type:         schema.TypeString,
+				Description:  "An absolute path to a Power BI template (PBIT) exported from the PBIX at `source`. Its model definition replaces the model in the PBIX when `strip_data` is set. Changing this value will require reuploading the PBIX.",
+				Optional:     true,
+				RequiredWith: []string{"strip_data"},
+			},
+			"strip_data_template_sha256": {
+				Type:        schema.TypeString,
+				Description: "The SHA-256 of the file at `strip_data_template`, calculated when planning. Any change to the template will trigger an upload.",
+				Computed:    true,
+			},
+			"theme_file": {
+				Type:        schema.TypeString,
+				Description: "An absolute path to a report theme JSON file, as exported from Power BI Desktop. The theme replaces any custom theme saved in the PBIX before uploading. Changing this value will require reuploading the PBIX.",
+				Optional:    true,
+			},
+			"theme_sha256": {
+				Type:        schema.TypeString,
+				Description: "The SHA-256 of the file at `theme_file`, calculated when planning. Any change to the theme will trigger an upload.",
+				Computed:    true,
+			},
+			"hidden_pages": {
+				Type:        schema.TypeSet,
+				Description: "Names or display names of report pages to hide before uploading. When set, all other pages are shown. At least one page must remain visible. Changing this value will require reuploading the PBIX.",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"refresh_after_deploy": {
+				Type:        schema.TypeBool,
+				Description: "If true, the PBIX dataset is refreshed after the PBIX is uploaded or parameters are changed, once parameters and datasources have been set.",
+				Optional:    true,
+				Default:     false,
+			},
+			"wait_for_refresh": {
+				Type:        schema.TypeBool,
+				Description: "If true, waits for the refresh triggered by `refresh_after_deploy` to complete, failing if the refresh fails. The wait is limited by the resource timeout.",
+				Optional:    true,
+				Default:     false,
+			},
+			"smoke_test_query": {
+				Type:        schema.TypeString,
+				Description: "A DAX query, such as `EVALUATE ROW(\"Rows\", COUNTROWS('Sales'))`, run against the PBIX dataset after it is deployed and refreshed. The deployment fails if the query errors or returns no rows. Setting this waits for the refresh as if `wait_for_refresh` was set.",
+				Optional:    true,
+			},
 			"report_id": {
 				Type:        schema.TypeString,
 				Description: "The ID for the report that was deployed as part of the PBIX.",
@@ -66,6 +199,18 @@ func ResourcePBIX() *schema.Resource {
 				Optional:      true,
 				ConflictsWith: []string{"parameter", "datasource"},
 			},
+			"take_over": {
+				Type:          schema.TypeBool,
+				Description:   "If true, the PBIX dataset will be taken over by the current user before parameters and datasources are updated. Required when the dataset is owned by another user or service principal. Datasets in \"My workspace\" are always owned by the current user, so this cannot be used with `my_workspace`.",
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"my_workspace"},
+			},
+			"configured_by": {
+				Type:        schema.TypeString,
+				Description: "The current owner of the PBIX dataset.",
+				Computed:    true,
+			},
 			"report_original_dataset_id": {
 				Type:        schema.TypeString,
 				Description: "The dataset to which the report that was deployed is pointing. This is primarily used to allow reverting rebinded datasets back to the original source.",
@@ -90,6 +235,44 @@ func ResourcePBIX() *schema.Resource {
 					},
 				},
 			},
+			"mashup_parameter": {
+				Type:        schema.TypeSet,
+				Description: "Power Query parameters whose default values are rewritten within the PBIX before it is uploaded. Unlike `parameter`, this can change parameters that are not marked as required. Changing this value will require reuploading the PBIX.",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The parameter name",
+							Required:    true,
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Description: "The parameter value. Values of text parameters are quoted, other values are written as Power Query expressions, for example `42` or `true`",
+							Required:    true,
+						},
+					},
+				},
+			},
+			"mashup_replacement": {
+				Type:        schema.TypeSet,
+				Description: "Text within the Power Query formulas to replace before the PBIX is uploaded, such as server names or URLs of data sources. Changing this value will require reuploading the PBIX.",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"original": {
+							Type:        schema.TypeString,
+							Description: "The text as it appears in the PBIX. Only text exactly matching a whole text value is replaced",
+							Required:    true,
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Description: "The text to replace it with",
+							Required:    true,
+						},
+					},
+				},
+			},
 			"datasource": {
 				Type:        schema.TypeSet,
 				Description: "Datasources to be reconfigured after deploying the PBIX dataset. Changing this value will require reuploading the PBIX. Any datasource updated will not be tracked",
@@ -141,21 +324,283 @@ func ResourcePBIX() *schema.Resource {
 	}
 }
 
-func openContentReader(d *schema.ResourceData) (io.Reader, error) {
+func openContentReader(d *schema.ResourceData) (io.ReadCloser, error) {
 	filepath := d.Get("source").(string)
-	return os.Open(filepath)
+
+	pipelineFuncs, err := buildPBIXPipelineFuncs(d)
+	if err != nil {
+		return nil, err
+	}
+	if len(pipelineFuncs) == 0 {
+		return os.Open(filepath)
+	}
+
+	tempFile, err := ioutil.TempFile("", "*.pbix")
+	if err != nil {
+		return nil, err
+	}
+	tempFile.Close()
+
+	err = pbixrewriter.RewritePbixFiles(filepath, tempFile.Name(), pipelineFuncs)
+	if err != nil {
+		os.Remove(tempFile.Name())
+		return nil, fmt.Errorf("failed to rewrite %s: %w", filepath, err)
+	}
+
+	file, err := os.Open(tempFile.Name())
+	if err != nil {
+		os.Remove(tempFile.Name())
+		return nil, err
+	}
+	return &tempFileReader{file}, nil
+}
+
+// tempFileReader removes the file once it has been read
+type tempFileReader struct {
+	*os.File
+}
+
+func (r *tempFileReader) Close() error {
+	err := r.File.Close()
+	os.Remove(r.File.Name())
+	return err
+}
+
+func buildPBIXPipelineFuncs(d *schema.ResourceData) ([]pbixrewriter.PipelineFunc, error) {
+	pipelineFuncs := []pbixrewriter.PipelineFunc{}
+
+	if d.Get("strip_data").(bool) {
+		dataModelSchema, err := pbixrewriter.ReadDataModelSchema(d.Get("strip_data_template").(string))
+		if err != nil {
+			return nil, err
+		}
+		pipelineFuncs = append(pipelineFuncs, pbixrewriter.StripDataPipelineFunc(dataModelSchema))
+	}
+
+	if parameters := convertNameValueSetToMap(d.Get("mashup_parameter").(*schema.Set), "name"); len(parameters) > 0 {
+		pipelineFuncs = append(pipelineFuncs, pbixrewriter.SetMashupParametersPipelineFunc(parameters))
+	}
+	if replacements := convertNameValueSetToMap(d.Get("mashup_replacement").(*schema.Set), "original"); len(replacements) > 0 {
+		pipelineFuncs = append(pipelineFuncs, pbixrewriter.ReplaceMashupStringsPipelineFunc(replacements))
+	}
+
+	if themeFile := d.Get("theme_file").(string); themeFile != "" {
+		theme, err := ioutil.ReadFile(themeFile)
+		if err != nil {
+			return nil, err
+		}
+		pipelineFuncs = append(pipelineFuncs, pbixrewriter.SetThemePipelineFunc(theme))
+	}
+	if hiddenPages := convertToStringSlice(d.Get("hidden_pages").(*schema.Set).List()); len(hiddenPages) > 0 {
+		pipelineFuncs = append(pipelineFuncs, pbixrewriter.SetPageVisibilityPipelineFunc(hiddenPages))
+	}
+
+	return pipelineFuncs, nil
+}
+
+func convertNameValueSetToMap(set *schema.Set, keyName string) map[string]string {
+	result := map[string]string{}
+	for _, item := range set.List() {
+		itemObj := item.(map[string]interface{})
+		result[itemObj[keyName].(string)] = itemObj["value"].(string)
+	}
+	return result
+}
+
+// pbixChangeReader reads changes from either a ResourceData or a ResourceDiff
+type pbixChangeReader interface {
+	Get(key string) interface{}
+	GetChange(key string) (interface{}, interface{})
+	HasChange(key string) bool
+}
+
+// isPBIXSourceSHA256Changed reports whether source_sha256 changed because the PBIX changed, rather than
+// only because normalize_source_hash was toggled on the same file
+func isPBIXSourceSHA256Changed(d pbixChangeReader) bool {
+	if !d.HasChange("source_sha256") {
+		return false
+	}
+	if d.HasChange("source") || !d.HasChange("normalize_source_hash") {
+		return true
+	}
+
+	oldNormalize, _ := d.GetChange("normalize_source_hash")
+	oldSHA256, _ := d.GetChange("source_sha256")
+	hash, err := hashPBIXFile(d.Get("source").(string), oldNormalize.(bool))
+	return err != nil || hash != oldSHA256.(string)
+}
+
+func customizePBIXSourceSHA256Diff(d *schema.ResourceDiff, meta interface{}) error {
+	// the source may be produced by another resource during apply, in which
+	// case the hash can only be known after the upload
+	if !d.NewValueKnown("source") || !d.NewValueKnown("normalize_source_hash") {
+		return d.SetNewComputed("source_sha256")
+	}
+
+	hash, err := hashPBIXFile(d.Get("source").(string), d.Get("normalize_source_hash").(bool))
+	if os.IsNotExist(err) {
+		return d.SetNewComputed("source_sha256")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to calculate hash of source: %w", err)
+	}
+
+	if hash != d.Get("source_sha256").(string) {
+		return d.SetNew("source_sha256", hash)
+	}
+	return nil
+}
+
+// customizePBIXFileSHA256Diff plans an upload when the content of a file that is
+// merged into the PBIX before uploading changes, by storing its hash in hashKey
+func customizePBIXFileSHA256Diff(fileKey string, hashKey string) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, meta interface{}) error {
+		if !d.NewValueKnown(fileKey) {
+			return d.SetNewComputed(hashKey)
+		}
+
+		hash := ""
+		if path := d.Get(fileKey).(string); path != "" {
+			var err error
+			hash, err = sha256File(path)
+			if os.IsNotExist(err) {
+				return d.SetNewComputed(hashKey)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to calculate hash of %s: %w", fileKey, err)
+			}
+		}
+
+		if hash != d.Get(hashKey).(string) {
+			return d.SetNew(hashKey, hash)
+		}
+		return nil
+	}
+}
+
+func customizePBIXContentSummaryDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("source") {
+		return d.SetNewComputed("content_summary")
+	}
+
+	summary, err := summarizePBIXContent(d.Get("source").(string))
+	if os.IsNotExist(err) {
+		return d.SetNewComputed("content_summary")
+	}
+	if err != nil {
+		// the summary is informational so a PBIX that cannot be read should still be uploaded
+		log.Printf("[WARN] Unable to summarize content of %s: %v", d.Get("source"), err)
+		return nil
+	}
+
+	if !reflect.DeepEqual(summary, d.Get("content_summary").(map[string]interface{})) {
+		return d.SetNew("content_summary", summary)
+	}
+	return nil
+}
+
+// summarizePBIXContent describes each component of a PBIX, keyed by component and name
+func summarizePBIXContent(path string) (map[string]interface{}, error) {
+	metadata, err := pbixrewriter.InspectPbixFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := map[string]interface{}{}
+	for _, component := range pbixrewriter.PbixComponents(metadata) {
+		summary[component.Key()] = shortenPBIXContentDescription(component.Description)
+	}
+	return summary, nil
+}
+
+// shortenPBIXContentDescription collapses whitespace and shortens long descriptions such as
+// M queries, keeping a hash so any change to the description is still shown
+func shortenPBIXContentDescription(description string) string {
+	description = strings.Join(strings.Fields(description), " ")
+	if len(description) <= 100 {
+		return description
+	}
+	hash := sha256.Sum256([]byte(description))
+	return fmt.Sprintf("%s... (sha256:%x)", strings.ToValidUTF8(description[:80], ""), hash[:4])
+}
+
+// hashPBIXFile returns the SHA-256 of a PBIX file. When normalized, only the
+// names and contents of the zip entries are hashed so that timestamps, compression
+// and the machine specific SecurityBindings entry do not affect the hash
+func hashPBIXFile(path string, normalize bool) (string, error) {
+	if !normalize {
+		return sha256File(path)
+	}
+
+	zipReader, err := zip.OpenReader(path)
+	if err != nil {
+		return "", err
+	}
+	defer zipReader.Close()
+
+	files := make([]*zip.File, 0, len(zipReader.File))
+	for _, file := range zipReader.File {
+		if file.Name == "SecurityBindings" {
+			continue
+		}
+		files = append(files, file)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Name < files[j].Name
+	})
+
+	hasher := sha256.New()
+	for _, file := range files {
+		entryHash, err := hashZipEntry(file)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(hasher, "%s\x00%s\n", file.Name, entryHash)
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+func hashZipEntry(file *zip.File) (string, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, reader); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
 }
 
 func createPBIX(d *schema.ResourceData, meta interface{}) error {
+	started := time.Now()
 
 	d.Partial(true)
 
-	err := createImport(d, meta)
+	adopted := false
+	if d.Get("adopt_existing").(bool) {
+		var err error
+		adopted, err = adoptExistingImport(d, meta)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !adopted {
+		err := createImport(d, meta, d.Get("name_conflict").(string))
+		if err != nil {
+			return err
+		}
+	}
+
+	err := readImport(d, meta, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
 		return err
 	}
 
-	err = readImport(d, meta, d.Timeout(schema.TimeoutCreate))
+	err = takeOverDatasetIfRequired(d, meta)
 	if err != nil {
 		return err
 	}
@@ -170,6 +615,11 @@ func createPBIX(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	err = refreshPBIXDatasetIfRequired(d, meta, d.Timeout(schema.TimeoutCreate)-time.Since(started))
+	if err != nil {
+		return err
+	}
+
 	if _, ok := d.GetOk("rebind_dataset_id"); ok {
 		err = rebindPBIXDataset(d, meta)
 		if err != nil {
@@ -186,7 +636,44 @@ func createPBIX(d *schema.ResourceData, meta interface{}) error {
 func readPBIX(d *schema.ResourceData, meta interface{}) error {
 
 	err := readImport(d, meta, d.Timeout(schema.TimeoutRead))
-	if isHTTP404Error(err) {
+	if powerbiapi.IsHTTP404Error(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// state created before source_sha256 existed is backfilled from the current
+	// file, so upgrading does not upload every PBIX again
+	if d.Get("source_sha256").(string) == "" && d.Get("source").(string) != "" {
+		sourceSHA256, err := hashPBIXFile(d.Get("source").(string), d.Get("normalize_source_hash").(bool))
+		if err == nil {
+			d.Set("source_sha256", sourceSHA256)
+		}
+	}
+	if d.Get("strip_data_template_sha256").(string) == "" && d.Get("strip_data_template").(string) != "" {
+		templateSHA256, err := sha256File(d.Get("strip_data_template").(string))
+		if err == nil {
+			d.Set("strip_data_template_sha256", templateSHA256)
+		}
+	}
+	if d.Get("theme_sha256").(string) == "" && d.Get("theme_file").(string) != "" {
+		themeSHA256, err := sha256File(d.Get("theme_file").(string))
+		if err == nil {
+			d.Set("theme_sha256", themeSHA256)
+		}
+	}
+	if len(d.Get("content_summary").(map[string]interface{})) == 0 && d.Get("source").(string) != "" {
+		summary, err := summarizePBIXContent(d.Get("source").(string))
+		if err == nil {
+			d.Set("content_summary", summary)
+		}
+	}
+
+	// the dataset may have been deleted outside of terraform while the import remains
+	err = readPBIXDataset(d, meta)
+	if powerbiapi.IsHTTP404Error(err) {
 		d.SetId("")
 		return nil
 	}
@@ -208,7 +695,12 @@ func readPBIX(d *schema.ResourceData, meta interface{}) error {
 }
 
 func updatePBIX(d *schema.ResourceData, meta interface{}) error {
-	if d.HasChange("source") || d.HasChange("source_hash") || d.HasChange("datasource") {
+	started := time.Now()
+
+	if d.HasChange("source") || d.HasChange("source_hash") || isPBIXSourceSHA256Changed(d) || d.HasChange("datasource") ||
+		d.HasChange("mashup_parameter") || d.HasChange("mashup_replacement") ||
+		d.HasChange("strip_data") || d.HasChange("strip_data_template") || d.HasChange("strip_data_template_sha256") ||
+		d.HasChange("theme_file") || d.HasChange("theme_sha256") || d.HasChange("hidden_pages") {
 
 		d.Partial(true)
 
@@ -218,7 +710,7 @@ func updatePBIX(d *schema.ResourceData, meta interface{}) error {
 			return err
 		}
 
-		err = createImport(d, meta)
+		err = createImport(d, meta, getPBIXUpdateNameConflict(d))
 		if err != nil {
 			return err
 		}
@@ -228,6 +720,11 @@ func updatePBIX(d *schema.ResourceData, meta interface{}) error {
 			return err
 		}
 
+		err = takeOverDatasetIfRequired(d, meta)
+		if err != nil {
+			return err
+		}
+
 		err = setPBIXParameters(d, meta)
 		if err != nil {
 			return err
@@ -238,6 +735,11 @@ func updatePBIX(d *schema.ResourceData, meta interface{}) error {
 			return err
 		}
 
+		err = refreshPBIXDatasetIfRequired(d, meta, d.Timeout(schema.TimeoutUpdate)-time.Since(started))
+		if err != nil {
+			return err
+		}
+
 		err = rebindPBIXDataset(d, meta)
 		if err != nil {
 			return err
@@ -261,7 +763,17 @@ func updatePBIX(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	if d.HasChange("parameter") {
-		err := setPBIXParameters(d, meta)
+		err := takeOverDatasetIfRequired(d, meta)
+		if err != nil {
+			return err
+		}
+
+		err = setPBIXParameters(d, meta)
+		if err != nil {
+			return err
+		}
+
+		err = refreshPBIXDatasetIfRequired(d, meta, d.Timeout(schema.TimeoutUpdate)-time.Since(started))
 		if err != nil {
 			return err
 		}
@@ -276,15 +788,16 @@ func deletePBIX(d *schema.ResourceData, meta interface{}) error {
 	groupID := d.Get("workspace_id").(string)
 
 	if reportID, reportIDOk := d.GetOk("report_id"); reportIDOk {
+		// adopted datasets and reports may already have been deleted by another resource
 		err := client.DeleteReportInGroup(groupID, reportID.(string))
-		if err != nil {
+		if err != nil && !powerbiapi.IsHTTP404Error(err) {
 			return err
 		}
 	}
 
 	if datasetID, datasetIDOk := d.GetOk("dataset_id"); datasetIDOk {
 		err := client.DeleteDatasetInGroup(groupID, datasetID.(string))
-		if err != nil {
+		if err != nil && !powerbiapi.IsHTTP404Error(err) {
 			return err
 		}
 	}
@@ -292,18 +805,19 @@ func deletePBIX(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func createImport(d *schema.ResourceData, meta interface{}) error {
+func createImport(d *schema.ResourceData, meta interface{}, nameConflict string) error {
 	client := meta.(*powerbiapi.Client)
 
 	reader, err := openContentReader(d)
 	if err != nil {
 		return err
 	}
+	defer reader.Close()
 
 	resp, err := client.PostImportInGroup(
 		d.Get("workspace_id").(string),
 		d.Get("name").(string),
-		"CreateOrOverwrite",
+		nameConflict,
 		d.Get("skip_report").(bool),
 		reader,
 	)
@@ -312,10 +826,85 @@ func createImport(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	d.SetId(resp.ID)
+	return setImportSourcePartial(d)
+}
+
+// adoptExistingImport sets the ID to the most recent successful import with the
+// same name, returning false if there is no such import to adopt
+func adoptExistingImport(d *schema.ResourceData, meta interface{}) (bool, error) {
+	client := meta.(*powerbiapi.Client)
+
+	imports, err := client.GetImportsInGroup(d.Get("workspace_id").(string))
+	if err != nil {
+		return false, err
+	}
+
+	var existing *powerbiapi.GetImportsInGroupResponseItem
+	for i, im := range imports.Value {
+		if im.ImportState != "Succeeded" || !strings.EqualFold(im.Name, d.Get("name").(string)) {
+			continue
+		}
+		if existing == nil || im.UpdatedDateTime.After(existing.UpdatedDateTime) {
+			existing = &imports.Value[i]
+		}
+	}
+	if existing == nil {
+		return false, nil
+	}
+
+	d.SetId(existing.ID)
+	return true, setImportSourcePartial(d)
+}
+
+func setImportSourcePartial(d *schema.ResourceData) error {
+	sourceSHA256, err := hashPBIXFile(d.Get("source").(string), d.Get("normalize_source_hash").(bool))
+	if err != nil {
+		return err
+	}
+
+	d.Set("source_sha256", sourceSHA256)
+	if summary, err := summarizePBIXContent(d.Get("source").(string)); err == nil {
+		d.Set("content_summary", summary)
+	}
 	d.SetPartial("workspace_id")
+	d.SetPartial("my_workspace")
 	d.SetPartial("source")
 	d.SetPartial("source_hash")
+	d.SetPartial("source_sha256")
+	d.SetPartial("content_summary")
+	d.SetPartial("normalize_source_hash")
+	d.SetPartial("name_conflict")
+	d.SetPartial("adopt_existing")
+	return nil
+}
+
+// getPBIXUpdateNameConflict returns the name conflict mode used when uploading
+// the PBIX again. The dataset and report already exist so must be overwritten
+func getPBIXUpdateNameConflict(d *schema.ResourceData) string {
+	if d.Get("name_conflict").(string) == "CreateOrOverwrite" {
+		return "CreateOrOverwrite"
+	}
+	return "Overwrite"
+}
+
+func customizePBIXNameConflictDiff(d *schema.ResourceDiff, meta interface{}) error {
+	// with these modes there may be several datasets with the same name, so an
+	// overwrite by name could replace a dataset this resource does not manage
+	nameConflict := d.Get("name_conflict").(string)
+	if d.Id() == "" || (nameConflict != "GenerateUniqueName" && nameConflict != "Ignore") {
+		return nil
+	}
 
+	for _, key := range []string{"source", "source_hash", "source_sha256", "datasource", "mashup_parameter", "mashup_replacement", "strip_data", "strip_data_template", "strip_data_template_sha256", "theme_file", "theme_sha256", "hidden_pages"} {
+		if key == "source_sha256" && !isPBIXSourceSHA256Changed(d) {
+			continue
+		}
+		if d.HasChange(key) {
+			if err := d.ForceNew(key); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
@@ -329,30 +918,53 @@ func readImport(d *schema.ResourceData, meta interface{}, timeoutForSuccessfulIm
 		return err
 	}
 
-	d.SetPartial("name")
-	d.Set("name", im.Name)
+	// generated names differ from the configured name, so are not tracked
+	if d.Get("name_conflict").(string) != "GenerateUniqueName" {
+		d.SetPartial("name")
+		d.Set("name", im.Name)
+	}
 
 	// powerbi imports can be modified by some operations (such as rebind)
 	// in order to keep reference to the original report and original dataset
-	// we will only look them up once after creation
-	if d.IsNewResource() {
-		if len(im.Reports) >= 1 {
-			d.SetPartial("report_id")
-			d.Set("report_id", im.Reports[0].ID)
+	// we will only look them up once after creation, or after terraform import
+	// when they are not yet known
+	if len(im.Reports) >= 1 && (d.IsNewResource() || d.Get("report_id").(string) == "") {
+		d.SetPartial("report_id")
+		d.Set("report_id", im.Reports[0].ID)
 
-			report, err := client.GetReportInGroup(groupID, im.Reports[0].ID)
-			if err != nil {
-				return err
-			}
-			d.SetPartial("report_original_dataset_id")
-			d.Set("report_original_dataset_id", report.DatasetID)
+		report, err := client.GetReportInGroup(groupID, im.Reports[0].ID)
+		if err != nil {
+			return err
 		}
+		d.SetPartial("report_original_dataset_id")
+		d.Set("report_original_dataset_id", report.DatasetID)
+	}
 
-		if len(im.Datasets) >= 1 {
-			d.SetPartial("dataset_id")
-			d.Set("dataset_id", im.Datasets[0].ID)
-		}
+	if len(im.Datasets) >= 1 && (d.IsNewResource() || d.Get("dataset_id").(string) == "") {
+		d.SetPartial("dataset_id")
+		d.Set("dataset_id", im.Datasets[0].ID)
+	}
+	return nil
+}
+
+func readPBIXDataset(d *schema.ResourceData, meta interface{}) error {
+
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+	datasetID, datasetOK := d.GetOk("dataset_id")
+
+	// some pbix do not have datasets
+	if !datasetOK {
+		return nil
 	}
+
+	dataset, err := client.GetDatasetInGroup(groupID, datasetID.(string))
+	if err != nil {
+		return err
+	}
+
+	d.Set("configured_by", dataset.ConfiguredBy)
 	return nil
 }
 
@@ -370,16 +982,7 @@ func setPBIXParameters(d *schema.ResourceData, meta interface{}) error {
 				return fmt.Errorf("Unable to update parameters on a PBIX file that does not contain a dataset")
 			}
 
-			updateParameterRequest := powerbiapi.UpdateParametersInGroupRequest{}
-			for _, parameterObj := range parameterList {
-				parameterObj := parameterObj.(map[string]interface{})
-				updateParameterRequest.UpdateDetails = append(updateParameterRequest.UpdateDetails, powerbiapi.UpdateParametersInGroupRequestItem{
-					Name:     parameterObj["name"].(string),
-					NewValue: parameterObj["value"].(string),
-				})
-			}
-
-			err := client.UpdateParametersInGroup(groupID, datasetID.(string), updateParameterRequest)
+			err := client.UpdateParametersInGroup(groupID, datasetID.(string), buildUpdateParametersInGroupRequest(parameterList))
 			if err != nil {
 				return err
 			}
@@ -391,6 +994,18 @@ func setPBIXParameters(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+func buildUpdateParametersInGroupRequest(parameterList []interface{}) powerbiapi.UpdateParametersInGroupRequest {
+	updateParameterRequest := powerbiapi.UpdateParametersInGroupRequest{}
+	for _, parameterObj := range parameterList {
+		parameterObj := parameterObj.(map[string]interface{})
+		updateParameterRequest.UpdateDetails = append(updateParameterRequest.UpdateDetails, powerbiapi.UpdateParametersInGroupRequestItem{
+			Name:     parameterObj["name"].(string),
+			NewValue: parameterObj["value"].(string),
+		})
+	}
+	return updateParameterRequest
+}
+
 func readPBIXParameters(d *schema.ResourceData, meta interface{}) error {
 
 	client := meta.(*powerbiapi.Client)
@@ -438,27 +1053,7 @@ func setPBIXDatasources(d *schema.ResourceData, meta interface{}) error {
 				return fmt.Errorf("Unable to update datasources on a PBIX file that does not contain a dataset")
 			}
 
-			updateDatasourcesRequest := powerbiapi.UpdateDatasourcesInGroupRequest{}
-			for _, datasourceObj := range datasourceList {
-				datasourceObj := datasourceObj.(map[string]interface{})
-				updateDatasourcesRequest.UpdateDetails = append(updateDatasourcesRequest.UpdateDetails, powerbiapi.UpdateDatasourcesInGroupRequestItem{
-					ConnectionDetails: powerbiapi.UpdateDatasourcesInGroupRequestItemConnectionDetails{
-						URL:      emptyStringToNil(datasourceObj["url"].(string)),
-						Database: emptyStringToNil(datasourceObj["database"].(string)),
-						Server:   emptyStringToNil(datasourceObj["server"].(string)),
-					},
-					DatasourceSelector: powerbiapi.UpdateDatasourcesInGroupRequestItemDatasourceSelector{
-						DatasourceType: datasourceObj["type"].(string),
-						ConnectionDetails: powerbiapi.UpdateDatasourcesInGroupRequestItemConnectionDetails{
-							URL:      emptyStringToNil(datasourceObj["original_url"].(string)),
-							Database: emptyStringToNil(datasourceObj["original_database"].(string)),
-							Server:   emptyStringToNil(datasourceObj["original_server"].(string)),
-						},
-					},
-				})
-			}
-
-			err := client.UpdateDatasourcesInGroup(groupID, datasetID.(string), updateDatasourcesRequest)
+			err := client.UpdateDatasourcesInGroup(groupID, datasetID.(string), buildUpdateDatasourcesInGroupRequest(datasourceList))
 			if err != nil {
 				return err
 			}
@@ -470,6 +1065,29 @@ func setPBIXDatasources(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+func buildUpdateDatasourcesInGroupRequest(datasourceList []interface{}) powerbiapi.UpdateDatasourcesInGroupRequest {
+	updateDatasourcesRequest := powerbiapi.UpdateDatasourcesInGroupRequest{}
+	for _, datasourceObj := range datasourceList {
+		datasourceObj := datasourceObj.(map[string]interface{})
+		updateDatasourcesRequest.UpdateDetails = append(updateDatasourcesRequest.UpdateDetails, powerbiapi.UpdateDatasourcesInGroupRequestItem{
+			ConnectionDetails: powerbiapi.UpdateDatasourcesInGroupRequestItemConnectionDetails{
+				URL:      emptyStringToNil(datasourceObj["url"].(string)),
+				Database: emptyStringToNil(datasourceObj["database"].(string)),
+				Server:   emptyStringToNil(datasourceObj["server"].(string)),
+			},
+			DatasourceSelector: powerbiapi.UpdateDatasourcesInGroupRequestItemDatasourceSelector{
+				DatasourceType: datasourceObj["type"].(string),
+				ConnectionDetails: powerbiapi.UpdateDatasourcesInGroupRequestItemConnectionDetails{
+					URL:      emptyStringToNil(datasourceObj["original_url"].(string)),
+					Database: emptyStringToNil(datasourceObj["original_database"].(string)),
+					Server:   emptyStringToNil(datasourceObj["original_server"].(string)),
+				},
+			},
+		})
+	}
+	return updateDatasourcesRequest
+}
+
 func readPBIXDatasources(d *schema.ResourceData, meta interface{}) error {
 
 	client := meta.(*powerbiapi.Client)
@@ -519,6 +1137,81 @@ func readPBIXDatasources(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+func refreshPBIXDatasetIfRequired(d *schema.ResourceData, meta interface{}, timeout time.Duration) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+	datasetID, datasetOk := d.GetOk("dataset_id")
+	smokeTestQuery := d.Get("smoke_test_query").(string)
+
+	// some pbix do not have datasets, and therefore cannot be refreshed
+	if !datasetOk {
+		return nil
+	}
+
+	if d.Get("refresh_after_deploy").(bool) {
+		wait := d.Get("wait_for_refresh").(bool) || smokeTestQuery != ""
+
+		// the refresh request does not return an ID, so we wait for a refresh
+		// newer than the latest one before the request
+		var previousRefreshID int64
+		if wait {
+			history, err := client.GetRefreshHistoryInGroup(groupID, datasetID.(string), 1)
+			if err != nil {
+				return err
+			}
+			if len(history.Value) > 0 {
+				previousRefreshID = history.Value[0].ID
+			}
+		}
+
+		err := client.RefreshDatasetInGroup(groupID, datasetID.(string), powerbiapi.RefreshDatasetInGroupRequest{
+			NotifyOption: "NoNotification",
+		})
+		if err != nil {
+			return fmt.Errorf("failed to refresh dataset %s: %w", datasetID, err)
+		}
+
+		if wait {
+			_, err = client.WaitForRefreshInGroupToComplete(groupID, datasetID.(string), previousRefreshID, timeout)
+			if err != nil {
+				return fmt.Errorf("failed to refresh dataset %s: %w", datasetID, err)
+			}
+		}
+	}
+
+	if smokeTestQuery != "" {
+		resp, err := client.ExecuteQueriesInGroup(groupID, datasetID.(string), powerbiapi.ExecuteQueriesInGroupRequest{
+			Queries: []powerbiapi.ExecuteQueriesInGroupRequestQuery{{Query: smokeTestQuery}},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to run smoke test query against dataset %s: %w", datasetID, err)
+		}
+		err = checkSmokeTestQueryResult(resp)
+		if err != nil {
+			return fmt.Errorf("smoke test query against dataset %s failed: %w", datasetID, err)
+		}
+	}
+
+	return nil
+}
+
+func checkSmokeTestQueryResult(resp *powerbiapi.ExecuteQueriesInGroupResponse) error {
+	rowCount := 0
+	for _, result := range resp.Results {
+		if result.Error != nil {
+			return fmt.Errorf("%s: %s", result.Error.Code, result.Error.Message)
+		}
+		for _, table := range result.Tables {
+			rowCount += len(table.Rows)
+		}
+	}
+	if rowCount == 0 {
+		return fmt.Errorf("query returned no rows")
+	}
+	return nil
+}
+
 func rebindPBIXDataset(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*powerbiapi.Client)
 
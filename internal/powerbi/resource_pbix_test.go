@@ -1,10 +1,13 @@
 package powerbi
 
 import (
+	"archive/zip"
 	"encoding/hex"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"math/rand"
+	"net/http"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -16,6 +19,7 @@ import (
 	"github.com/codecutout/terraform-provider-powerbi/internal/powerbiapi"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 )
 
@@ -84,6 +88,23 @@ func TestAccPBIX_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("powerbi_pbix.test", "name", "Acceptance Test PBIX"),
 				),
 			},
+			// import by name finds the report and dataset of the import
+			{
+				ResourceName:  "powerbi_pbix.test",
+				ImportState:   true,
+				ImportStateId: fmt.Sprintf("Acceptance Test Workspace %s/Acceptance Test PBIX", workspaceSuffix),
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected 1 imported resource, got %d", len(states))
+					}
+					for _, key := range []string{"report_id", "dataset_id", "report_original_dataset_id"} {
+						if states[0].Attributes[key] == "" {
+							return fmt.Errorf("expected %s to be set after import", key)
+						}
+					}
+					return nil
+				},
+			},
 			// deletes the resource
 			{
 				Config: fmt.Sprintf(`
@@ -822,6 +843,486 @@ func TestAccPBIX_datasources(t *testing.T) {
 	})
 }
 
+func TestAccPBIX_source_sha256(t *testing.T) {
+	var updatedTime time.Time
+	var firstSHA256 string
+	pbixLocation := TempFileName("", ".pbix")
+	pbixLocationTfFriendly := strings.ReplaceAll(pbixLocation, "\\", "\\\\")
+	workspaceSuffix := acctest.RandString(6)
+	config := fmt.Sprintf(`
+	resource "powerbi_workspace" "test" {
+		name = "Acceptance Test Workspace %s"
+	}
+
+	resource "powerbi_pbix" "test" {
+		workspace_id = "${powerbi_workspace.test.id}"
+		name = "Acceptance Test PBIX"
+		source = "%s"
+		normalize_source_hash = true
+	}
+	`, workspaceSuffix, pbixLocationTfFriendly)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerbiWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			// first step creates the resource without a source_hash
+			{
+				PreConfig: func() {
+					Copy("./resource_pbix_test_sample1.pbix", pbixLocation)
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					setUpdatedTime("powerbi_pbix.test", &updatedTime),
+					set("powerbi_pbix.test", "source_sha256", &firstSHA256),
+				),
+			},
+			// changing the file at the same path uploads it again
+			{
+				PreConfig: func() {
+					Copy("./resource_pbix_test_sample2.pbix", pbixLocation)
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckUpdatedAfter("powerbi_pbix.test", &updatedTime),
+					func(s *terraform.State) error {
+						sha256, err := getResourceProperty(s, "powerbi_pbix.test", "source_sha256")
+						if err != nil {
+							return err
+						}
+						if sha256 == firstSHA256 {
+							return fmt.Errorf("expected source_sha256 to change from %s", firstSHA256)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccPBIX_mashup(t *testing.T) {
+	workspaceSuffix := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerbiWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			// parameters and data source URLs are rewritten before upload
+			{
+				Config: fmt.Sprintf(`
+				resource "powerbi_workspace" "test" {
+					name = "Acceptance Test Workspace %s"
+				}
+
+				resource "powerbi_pbix" "test" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name = "Acceptance Test PBIX"
+					source = "./resource_pbix_test_sample1.pbix"
+					source_hash = "${filemd5("./resource_pbix_test_sample1.pbix")}"
+					mashup_parameter {
+						name = "ParamOne"
+						value = "RewrittenValue"
+					}
+					mashup_replacement {
+						original = "https://services.odata.org/V3/OData/OData.svc/"
+						value = "https://services.odata.org/V3/(S(terraform))/OData/OData.svc/"
+					}
+				}
+				`, workspaceSuffix),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckParameter("powerbi_pbix.test", "ParamOne", "RewrittenValue"),
+					testCheckParameter("powerbi_pbix.test", "ParamTwo", "ParamTwoValue"),
+					testCheckURLDatasource("powerbi_pbix.test", "https://services.odata.org/V3/(S(terraform))/OData/OData.svc/"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPBIX_theme(t *testing.T) {
+	workspaceSuffix := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerbiWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			// theme is added to the PBIX before upload
+			{
+				Config: testAccPBIXThemeConfig(workspaceSuffix, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_pbix.test", "theme_file", "./resource_pbix_test_theme.json"),
+					resource.TestCheckResourceAttrSet("powerbi_pbix.test", "theme_sha256"),
+					resource.TestCheckResourceAttrSet("powerbi_pbix.test", "report_id"),
+				),
+			},
+			// the only page of the report cannot be hidden
+			{
+				Config:      testAccPBIXThemeConfig(workspaceSuffix, `hidden_pages = ["Page 1"]`),
+				ExpectError: regexp.MustCompile("at least one page must be visible"),
+			},
+		},
+	})
+}
+
+func testAccPBIXThemeConfig(workspaceSuffix string, extraAttributes string) string {
+	return fmt.Sprintf(`
+	resource "powerbi_workspace" "test" {
+		name = "Acceptance Test Workspace %s"
+	}
+
+	resource "powerbi_pbix" "test" {
+		workspace_id = "${powerbi_workspace.test.id}"
+		name = "Acceptance Test PBIX"
+		source = "./resource_pbix_test_sample1.pbix"
+		theme_file = "./resource_pbix_test_theme.json"
+		%s
+	}
+	`, workspaceSuffix, extraAttributes)
+}
+
+func TestSummarizePBIXContent(t *testing.T) {
+	summary, err := summarizePBIXContent("./resource_pbix_test_sample1.pbix")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if summary["page Page 1"] != "ReportSection, position 0" {
+		t.Errorf("unexpected page summary %v", summary["page Page 1"])
+	}
+	if summary["visual Page 1/09072f927c1a4121c6b9"] != "tableEx at (10, 0) size 280x280, fields Names.Name" {
+		t.Errorf("unexpected visual summary %v", summary["visual Page 1/09072f927c1a4121c6b9"])
+	}
+	if summary["query ParamOne"] != `"ParamOneValue" meta [IsParameterQuery=true, Type="Text", IsParameterQueryRequired=true]` {
+		t.Errorf("unexpected query summary %v", summary["query ParamOne"])
+	}
+	if products := summary["query Products"].(string); len(products) > 110 || !strings.Contains(products, "... (sha256:") {
+		t.Errorf("expected long query to be shortened, got %v", products)
+	}
+}
+
+func TestHashPBIXFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pbix_hash")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	writePBIX := func(name string, modified time.Time, entries map[string]string) string {
+		path := filepath.Join(dir, name)
+		file, err := os.Create(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer file.Close()
+
+		zipWriter := zip.NewWriter(file)
+		for _, entryName := range []string{"Version", "DataModel", "SecurityBindings"} {
+			content, ok := entries[entryName]
+			if !ok {
+				continue
+			}
+			writer, err := zipWriter.CreateHeader(&zip.FileHeader{Name: entryName, Method: zip.Deflate, Modified: modified})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			writer.Write([]byte(content))
+		}
+		if err := zipWriter.Close(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return path
+	}
+
+	original := writePBIX("original.pbix", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), map[string]string{
+		"Version":   "1.28",
+		"DataModel": "model",
+	})
+	resaved := writePBIX("resaved.pbix", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), map[string]string{
+		"Version":          "1.28",
+		"DataModel":        "model",
+		"SecurityBindings": "machine specific",
+	})
+	changed := writePBIX("changed.pbix", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), map[string]string{
+		"Version":   "1.28",
+		"DataModel": "changed model",
+	})
+
+	hash := func(path string, normalize bool) string {
+		result, err := hashPBIXFile(path, normalize)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return result
+	}
+
+	if hash(original, true) != hash(resaved, true) {
+		t.Errorf("expected normalized hash to ignore timestamps and SecurityBindings")
+	}
+	if hash(original, true) == hash(changed, true) {
+		t.Errorf("expected normalized hash to change when content changes")
+	}
+	if hash(original, false) == hash(resaved, false) {
+		t.Errorf("expected hash without normalization to change when file is resaved")
+	}
+
+	fileHash, _ := sha256File(original)
+	if hash(original, false) != fileHash {
+		t.Errorf("expected hash without normalization to be the SHA-256 of the file")
+	}
+}
+
+func TestIsPBIXSourceSHA256Changed(t *testing.T) {
+	source := "./resource_pbix_test_sample1.pbix"
+	rawHash, err := hashPBIXFile(source, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	normalizedHash, err := hashPBIXFile(source, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		old      map[string]interface{}
+		new      map[string]interface{}
+		expected bool
+	}{
+		{"unchanged", map[string]interface{}{"source_sha256": rawHash, "normalize_source_hash": false}, map[string]interface{}{"source_sha256": rawHash, "normalize_source_hash": false}, false},
+		{"normalize enabled", map[string]interface{}{"source_sha256": rawHash, "normalize_source_hash": false}, map[string]interface{}{"source_sha256": normalizedHash, "normalize_source_hash": true}, false},
+		{"normalize disabled", map[string]interface{}{"source_sha256": normalizedHash, "normalize_source_hash": true}, map[string]interface{}{"source_sha256": rawHash, "normalize_source_hash": false}, false},
+		{"file changed", map[string]interface{}{"source_sha256": "previous", "normalize_source_hash": false}, map[string]interface{}{"source_sha256": rawHash, "normalize_source_hash": false}, true},
+		{"file changed and normalize enabled", map[string]interface{}{"source_sha256": "previous", "normalize_source_hash": false}, map[string]interface{}{"source_sha256": normalizedHash, "normalize_source_hash": true}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.old["source"] = source
+			tt.new["source"] = source
+			if actual := isPBIXSourceSHA256Changed(testPBIXChangeReader{old: tt.old, new: tt.new}); actual != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+// testPBIXChangeReader holds the values before and after a change
+type testPBIXChangeReader struct {
+	old map[string]interface{}
+	new map[string]interface{}
+}
+
+func (r testPBIXChangeReader) Get(key string) interface{} {
+	return r.new[key]
+}
+
+func (r testPBIXChangeReader) GetChange(key string) (interface{}, interface{}) {
+	return r.old[key], r.new[key]
+}
+
+func (r testPBIXChangeReader) HasChange(key string) bool {
+	return r.old[key] != r.new[key]
+}
+
+func TestAccPBIX_adopt_existing(t *testing.T) {
+	workspaceSuffix := acctest.RandString(6)
+	originalConfig := fmt.Sprintf(`
+	resource "powerbi_workspace" "test" {
+		name = "Acceptance Test Workspace %s"
+	}
+
+	resource "powerbi_pbix" "test" {
+		workspace_id = "${powerbi_workspace.test.id}"
+		name = "Acceptance Test PBIX"
+		source = "./resource_pbix_test_sample1.pbix"
+		source_hash = "${filemd5("./resource_pbix_test_sample1.pbix")}"
+	}
+	`, workspaceSuffix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerbiWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			// first step uploads the PBIX
+			{
+				Config: originalConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("powerbi_pbix.test", "name_conflict", "CreateOrOverwrite"),
+				),
+			},
+			// uploading with the same name is rejected when aborting on conflicts
+			{
+				Config: originalConfig + `
+				resource "powerbi_pbix" "conflict" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name = "Acceptance Test PBIX"
+					source = "./resource_pbix_test_sample1.pbix"
+					name_conflict = "Abort"
+				}
+				`,
+				ExpectError: regexp.MustCompile("(?i)invalid state|conflict|duplicate"),
+			},
+			// adopting brings the existing dataset and report under management
+			{
+				Config: originalConfig + `
+				resource "powerbi_pbix" "adopted" {
+					workspace_id = "${powerbi_workspace.test.id}"
+					name = "Acceptance Test PBIX"
+					source = "./resource_pbix_test_sample1.pbix"
+					adopt_existing = true
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("powerbi_pbix.adopted", "id", "powerbi_pbix.test", "id"),
+					resource.TestCheckResourceAttrPair("powerbi_pbix.adopted", "dataset_id", "powerbi_pbix.test", "dataset_id"),
+					resource.TestCheckResourceAttrPair("powerbi_pbix.adopted", "report_id", "powerbi_pbix.test", "report_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestGetPBIXUpdateNameConflict(t *testing.T) {
+	testCases := map[string]string{
+		"Abort":              "Overwrite",
+		"Overwrite":          "Overwrite",
+		"CreateOrOverwrite":  "CreateOrOverwrite",
+		"GenerateUniqueName": "Overwrite",
+		"Ignore":             "Overwrite",
+	}
+
+	for nameConflict, expected := range testCases {
+		d := schema.TestResourceDataRaw(t, ResourcePBIX().Schema, map[string]interface{}{
+			"name_conflict": nameConflict,
+		})
+		if actual := getPBIXUpdateNameConflict(d); actual != expected {
+			t.Errorf("expected %s for %s, got %s", expected, nameConflict, actual)
+		}
+	}
+}
+
+func TestReadImport_imported(t *testing.T) {
+	client := &powerbiapi.Client{Client: &http.Client{Transport: testPowerbiRoundTripper{
+		"/v1.0/myorg/groups/workspace/imports/import": `{"id":"import","importState":"Succeeded","name":"Sales","reports":[{"id":"report"}],"datasets":[{"id":"dataset"}]}`,
+		"/v1.0/myorg/groups/workspace/reports/report": `{"id":"report","datasetId":"original-dataset"}`,
+	}}}
+
+	// terraform import only sets the workspace and ID before the first read
+	d := schema.TestResourceDataRaw(t, ResourcePBIX().Schema, map[string]interface{}{"workspace_id": "workspace"})
+	d.SetId("import")
+
+	if err := readImport(d, client, time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]string{"report_id": "report", "dataset_id": "dataset", "report_original_dataset_id": "original-dataset"}
+	for key, value := range expected {
+		if actual := d.Get(key).(string); actual != value {
+			t.Errorf("expected %s to be %s, got %s", key, value, actual)
+		}
+	}
+}
+
+// testPowerbiRoundTripper responds to requests with the JSON body for the request path
+type testPowerbiRoundTripper map[string]string
+
+func (r testPowerbiRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	body, ok := r[request.URL.Path]
+	if !ok {
+		return &http.Response{StatusCode: 404, Body: ioutil.NopCloser(strings.NewReader("")), Request: request}, nil
+	}
+	return &http.Response{StatusCode: 200, Header: http.Header{"Content-Type": []string{"application/json"}}, Body: ioutil.NopCloser(strings.NewReader(body)), Request: request}, nil
+}
+
+func TestAccPBIX_smoke_test_query(t *testing.T) {
+	workspaceSuffix := acctest.RandString(6)
+	config := func(smokeTestQuery string) string {
+		return fmt.Sprintf(`
+		resource "powerbi_workspace" "test" {
+			name = "Acceptance Test Workspace %s"
+		}
+
+		resource "powerbi_pbix" "test" {
+			workspace_id = "${powerbi_workspace.test.id}"
+			name = "Acceptance Test PBIX"
+			source = "./resource_pbix_test_sample1.pbix"
+			source_hash = "${filemd5("./resource_pbix_test_sample1.pbix")}"
+			smoke_test_query = %q
+		}
+		`, workspaceSuffix, smokeTestQuery)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerbiWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			// a query returning rows allows the deployment to succeed
+			{
+				Config: config(`EVALUATE ROW("One", 1)`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("powerbi_pbix.test", "dataset_id"),
+				),
+			},
+			// a query returning no rows fails the deployment
+			{
+				Config:      config(`EVALUATE FILTER(ROW("One", 1), FALSE())`),
+				ExpectError: regexp.MustCompile("query returned no rows"),
+			},
+		},
+	})
+}
+
+func TestCheckSmokeTestQueryResult(t *testing.T) {
+	testCases := []struct {
+		name        string
+		resp        powerbiapi.ExecuteQueriesInGroupResponse
+		expectError bool
+	}{
+		{
+			name: "rows",
+			resp: powerbiapi.ExecuteQueriesInGroupResponse{Results: []powerbiapi.ExecuteQueriesInGroupResponseResult{
+				{Tables: []powerbiapi.ExecuteQueriesInGroupResponseTable{{Rows: []map[string]interface{}{{"[One]": 1}}}}},
+			}},
+		},
+		{
+			name: "no rows",
+			resp: powerbiapi.ExecuteQueriesInGroupResponse{Results: []powerbiapi.ExecuteQueriesInGroupResponseResult{
+				{Tables: []powerbiapi.ExecuteQueriesInGroupResponseTable{{Rows: []map[string]interface{}{}}}},
+			}},
+			expectError: true,
+		},
+		{
+			name:        "no results",
+			resp:        powerbiapi.ExecuteQueriesInGroupResponse{},
+			expectError: true,
+		},
+		{
+			name: "query error",
+			resp: powerbiapi.ExecuteQueriesInGroupResponse{Results: []powerbiapi.ExecuteQueriesInGroupResponseResult{
+				{Error: &powerbiapi.ExecuteQueriesInGroupResponseError{Code: "DatasetExecuteQueriesError", Message: "Query (1, 1) Failed"}},
+			}},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkSmokeTestQueryResult(&tc.resp)
+			if tc.expectError && err == nil {
+				t.Errorf("expected error")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
 // TempFileName generates a temporary filename for use in testing or whatever
 func TempFileName(prefix, suffix string) string {
 	randBytes := make([]byte, 16)
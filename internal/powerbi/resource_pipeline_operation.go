@@ -12,16 +12,23 @@ import (
 // ResourcePipelineOperation represents a Power BI deployment pipeline operation
 func ResourcePipelineOperation() *schema.Resource {
 	return &schema.Resource{
+		Description: "deploys content from one stage of a deployment pipeline to the next.",
+
 		Create: createPipelineOperation,
 		Read:   readPipelineOperation,
 		Delete: deletePipelineOperation,
 		Importer: &schema.ResourceImporter{
 			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				client := meta.(*powerbiapi.Client)
 				parts := strings.Split(d.Id(), "/")
 				if len(parts) != 2 {
-					return nil, fmt.Errorf("invalid pipeline operation import id format, expected 'pipeline_id/operation_id'")
+					return nil, fmt.Errorf("invalid pipeline operation import id format, expected 'pipeline_id/operation_id' or 'pipeline_name/operation_id'")
+				}
+				pipelineID, err := resolveImportPipelineID(client, parts[0])
+				if err != nil {
+					return nil, err
 				}
-				d.Set("pipeline_id", parts[0])
+				d.Set("pipeline_id", pipelineID)
 				d.SetId(parts[1])
 				return []*schema.ResourceData{d}, nil
 			},
@@ -12,22 +12,29 @@ import (
 // ResourcePipelineStage represents a Power BI deployment pipeline stage
 func ResourcePipelineStage() *schema.Resource {
 	return &schema.Resource{
+		Description: "assigns a workspace to a stage of a deployment pipeline.",
+
 		Create: createPipelineStage,
 		Read:   readPipelineStage,
 		Delete: deletePipelineStage,
 		Importer: &schema.ResourceImporter{
 			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				client := meta.(*powerbiapi.Client)
 				parts := strings.Split(d.Id(), "/")
 				if len(parts) != 2 {
-					return nil, fmt.Errorf("invalid pipeline stage import id format, expected 'pipeline_id/stage_order'")
+					return nil, fmt.Errorf("invalid pipeline stage import id format, expected 'pipeline_id/stage_order' or 'pipeline_name/stage_order'")
 				}
-				d.Set("pipeline_id", parts[0])
 				stageOrder, err := strconv.Atoi(parts[1])
 				if err != nil {
 					return nil, fmt.Errorf("invalid stage order: %w", err)
 				}
+				pipelineID, err := resolveImportPipelineID(client, parts[0])
+				if err != nil {
+					return nil, err
+				}
+				d.Set("pipeline_id", pipelineID)
 				d.Set("stage_order", stageOrder)
-				d.SetId(fmt.Sprintf("%s/%d", parts[0], stageOrder))
+				d.SetId(fmt.Sprintf("%s/%d", pipelineID, stageOrder))
 				return []*schema.ResourceData{d}, nil
 			},
 		},
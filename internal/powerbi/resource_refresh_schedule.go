@@ -18,16 +18,26 @@ func ResourceRefreshSchedule() *schema.Resource {
 		Delete: deleteRefreshSchedule,
 		Importer: &schema.ResourceImporter{
 			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				client := meta.(*powerbiapi.Client)
 				idParts := strings.Split(d.Id(), "/")
 				if len(idParts) != 2 {
-					return nil, fmt.Errorf("invalid import ID, expected format: workspace_id/dataset_id")
+					return nil, fmt.Errorf("invalid import ID, expected format: workspace_id/dataset_id or workspace_name/dataset_name")
 				}
-				d.Set("workspace_id", idParts[0])
-				d.Set("dataset_id", idParts[1])
-				d.SetId(fmt.Sprintf("%s/%s", idParts[0], idParts[1]))
+				groupID, err := resolveImportWorkspaceID(client, idParts[0])
+				if err != nil {
+					return nil, err
+				}
+				datasetID, err := resolveImportDatasetID(client, groupID, idParts[1])
+				if err != nil {
+					return nil, err
+				}
+				d.Set("workspace_id", groupID)
+				d.Set("dataset_id", datasetID)
+				d.SetId(fmt.Sprintf("%s/%s", groupID, datasetID))
 				return []*schema.ResourceData{d}, nil
 			},
 		},
+		CustomizeDiff: customizeRefreshScheduleDiff,
 
 		Schema: map[string]*schema.Schema{
 			"workspace_id": {
@@ -45,7 +55,8 @@ func ResourceRefreshSchedule() *schema.Resource {
 			"days": {
 				Type: schema.TypeList,
 				Elem: &schema.Schema{
-					Type: schema.TypeString,
+					Type:         schema.TypeString,
+					ValidateFunc: validateRefreshScheduleDay,
 				},
 				Description: "The list of days of the week when the schedule should refresh.",
 				Required:    true,
@@ -53,9 +64,10 @@ func ResourceRefreshSchedule() *schema.Resource {
 			"times": {
 				Type: schema.TypeList,
 				Elem: &schema.Schema{
-					Type: schema.TypeString,
+					Type:         schema.TypeString,
+					ValidateFunc: validateRefreshScheduleTime,
 				},
-				Description: "The list of times on the day the schedule should refresh. Times should be in the format HH:00 or HH:30 i.e. Hour should be two digits and minutes must either be on the full or half hour.",
+				Description: "The list of times on the day the schedule should refresh. Times should be in the format HH:00 or HH:30 i.e. Hour should be two digits and minutes must either be on the full or half hour. Times must be unique, and at most 8 times can be set for workspaces on shared capacity or 48 for workspaces on Premium capacity.",
 				Required:    true,
 			},
 			"enabled": {
@@ -65,10 +77,12 @@ func ResourceRefreshSchedule() *schema.Resource {
 				Default:     true,
 			},
 			"local_time_zone_id": {
-				Type:        schema.TypeString,
-				Description: "The name of the timezone to use. See Name of Time Zone column in [Microsoft Time Zone Index Values](https://support.microsoft.com/en-gb/help/973627/microsoft-time-zone-index-values).",
-				Optional:    true,
-				Default:     "UTC",
+				Type:         schema.TypeString,
+				Description:  "The name of the timezone to use. See Name of Time Zone column in [Microsoft Time Zone Index Values](https://support.microsoft.com/en-gb/help/973627/microsoft-time-zone-index-values). IANA time zones such as `Europe/London` are converted to the equivalent Windows time zone.",
+				Optional:     true,
+				Default:      "UTC",
+				ValidateFunc: validateRefreshScheduleTimeZone,
+				StateFunc:    refreshScheduleTimeZoneStateFunc,
 			},
 			"notify_option": {
 				Type:        schema.TypeString,
@@ -109,49 +123,7 @@ func getGroupID(d *schema.ResourceData, meta interface{}) (string, error) {
 	return groupID, nil
 }
 
-func validateConfig(d *schema.ResourceData, meta interface{}) error {
-	// schema validate functions do not yet support lists and maps
-	// creating own makeshift validation to check days and times
-
-	err := validateConfigDays(d, meta)
-	if err != nil {
-		return err
-	}
-	err = validateConfigTimes(d, meta)
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
-func validateConfigDays(d *schema.ResourceData, meta interface{}) error {
-	reg := regexp.MustCompile("^(Monday|Tuesday|Wednesday|Thursday|Friday|Saturday|Sunday)$")
-	days := convertToStringSlice(d.Get("days").([]interface{}))
-	for _, day := range days {
-		if !reg.MatchString(day) {
-			return fmt.Errorf("config is invalid: Expected argument 'days' to be either 'Monday', 'Tuesday', 'Wednesday', 'Thursday', 'Friday', 'Saturday' or 'Sunday'. Found '%v'", day)
-		}
-	}
-	return nil
-}
-
-func validateConfigTimes(d *schema.ResourceData, meta interface{}) error {
-	reg := regexp.MustCompile("^(0[0-9]|1[0-9]|2[0-3]):(00|30)$")
-	times := convertToStringSlice(d.Get("times").([]interface{}))
-	for _, time := range times {
-		if !reg.MatchString(time) {
-			return fmt.Errorf("config is invalid: Expected argument 'times' to be in the format 'HH:00' or 'HH:30'. Hours must be two digits and must be on the hour or half hour. Found time '%v'", time)
-		}
-	}
-	return nil
-}
-
 func createRefreshSchedule(d *schema.ResourceData, meta interface{}) error {
-	err := validateConfig(d, meta)
-	if err != nil {
-		return err
-	}
-
 	client := meta.(*powerbiapi.Client)
 
 	enabled := nilIfFalse(d.Get("enabled").(bool))
@@ -165,15 +137,7 @@ func createRefreshSchedule(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	err = client.UpdateRefreshScheduleInGroup(groupID, datasetID, powerbiapi.UpdateRefreshScheduleInGroupRequest{
-		Value: powerbiapi.UpdateRefreshScheduleInGroupRequestValue{
-			Enabled:         convertBoolToPointer(true), // API doesnt allow updating if disabled
-			Days:            convertStringSliceToPointer(convertToStringSlice(d.Get("days").([]interface{}))),
-			Times:           convertStringSliceToPointer(convertToStringSlice(d.Get("times").([]interface{}))),
-			LocalTimeZoneID: convertStringToPointer(d.Get("local_time_zone_id").(string)),
-			NotifyOption:    convertStringToPointer(d.Get("notify_option").(string)),
-		},
-	})
+	err = client.UpdateRefreshScheduleInGroup(groupID, datasetID, buildCreateRefreshScheduleRequest(d))
 	if err != nil {
 		return err
 	}
@@ -193,6 +157,20 @@ func createRefreshSchedule(d *schema.ResourceData, meta interface{}) error {
 	return readRefreshSchedule(d, meta)
 }
 
+// buildCreateRefreshScheduleRequest builds the request that sets the whole schedule. Config values are
+// read before the StateFunc is applied, so IANA time zones are converted here as well
+func buildCreateRefreshScheduleRequest(d *schema.ResourceData) powerbiapi.UpdateRefreshScheduleInGroupRequest {
+	return powerbiapi.UpdateRefreshScheduleInGroupRequest{
+		Value: powerbiapi.UpdateRefreshScheduleInGroupRequestValue{
+			Enabled:         convertBoolToPointer(true), // API doesnt allow updating if disabled
+			Days:            convertStringSliceToPointer(convertToStringSlice(d.Get("days").([]interface{}))),
+			Times:           convertStringSliceToPointer(convertToStringSlice(d.Get("times").([]interface{}))),
+			LocalTimeZoneID: convertStringToPointer(normalizeRefreshScheduleTimeZone(d.Get("local_time_zone_id").(string))),
+			NotifyOption:    convertStringToPointer(d.Get("notify_option").(string)),
+		},
+	}
+}
+
 func readRefreshSchedule(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*powerbiapi.Client)
 
@@ -206,7 +184,7 @@ func readRefreshSchedule(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	refreshSchedule, err := client.GetRefreshScheduleInGroup(groupID, datasetID)
-	if isHTTP404Error(err) {
+	if powerbiapi.IsHTTP404Error(err) {
 		d.SetId("")
 		return nil
 	}
@@ -227,11 +205,6 @@ func readRefreshSchedule(d *schema.ResourceData, meta interface{}) error {
 }
 
 func updateRefreshSchedule(d *schema.ResourceData, meta interface{}) error {
-	err := validateConfig(d, meta)
-	if err != nil {
-		return err
-	}
-
 	client := meta.(*powerbiapi.Client)
 
 	requestVal := powerbiapi.UpdateRefreshScheduleInGroupRequestValue{}
@@ -256,7 +229,7 @@ func updateRefreshSchedule(d *schema.ResourceData, meta interface{}) error {
 		updateRequired = true
 	}
 	if d.HasChange("local_time_zone_id") {
-		requestVal.LocalTimeZoneID = convertStringToPointer(d.Get("local_time_zone_id").(string))
+		requestVal.LocalTimeZoneID = convertStringToPointer(normalizeRefreshScheduleTimeZone(d.Get("local_time_zone_id").(string)))
 		updateRequired = true
 	}
 	if d.HasChange("notify_option") {
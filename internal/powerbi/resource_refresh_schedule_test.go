@@ -9,6 +9,7 @@ import (
 	"github.com/codecutout/terraform-provider-powerbi/internal/powerbiapi"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 )
 
@@ -190,6 +191,31 @@ func TestAccRefreshSchedule_validation(t *testing.T) {
 				`,
 				ExpectError: regexp.MustCompile("config is invalid:.*times.*"),
 			},
+			{
+				Config: `
+				resource "powerbi_refresh_schedule" "test" {
+					dataset_id = "validation-should-fail-before-using-this"
+					workspace_id = "validation-should-fail-before-using-this"
+					times = []
+					days = []
+					local_time_zone_id = "Mars/Olympus_Mons"
+				}
+
+				`,
+				ExpectError: regexp.MustCompile("config is invalid:.*local_time_zone_id.*"),
+			},
+			{
+				Config: `
+				resource "powerbi_refresh_schedule" "test" {
+					dataset_id = "validation-should-fail-before-using-this"
+					workspace_id = "validation-should-fail-before-using-this"
+					times = ["09:00", "17:30", "09:00"]
+					days = []
+				}
+
+				`,
+				ExpectError: regexp.MustCompile("Expected argument 'times' to contain unique times"),
+			},
 		},
 	})
 }
@@ -278,6 +304,27 @@ func TestAccRefreshSchedule_skew(t *testing.T) {
 	})
 }
 
+func TestBuildRefreshScheduleRequests_ianaTimeZone(t *testing.T) {
+	raw := map[string]interface{}{
+		"dataset_id":         "dataset",
+		"days":               []interface{}{"Monday"},
+		"times":              []interface{}{"09:00"},
+		"local_time_zone_id": "Europe/London",
+	}
+
+	// the API only accepts Windows time zone IDs
+	request := buildCreateRefreshScheduleRequest(schema.TestResourceDataRaw(t, ResourceRefreshSchedule().Schema, raw))
+	if request.Value.LocalTimeZoneID == nil || *request.Value.LocalTimeZoneID != "GMT Standard Time" {
+		t.Errorf("expected dataset refresh schedule time zone GMT Standard Time, got %v", request.Value.LocalTimeZoneID)
+	}
+
+	raw["dataflow_id"] = "dataflow"
+	dataflowSchedule := buildDataflowRefreshSchedule(schema.TestResourceDataRaw(t, ResourceDataflowRefreshSchedule().Schema, raw))
+	if dataflowSchedule.LocalTimeZoneID != "GMT Standard Time" {
+		t.Errorf("expected dataflow refresh schedule time zone GMT Standard Time, got %s", dataflowSchedule.LocalTimeZoneID)
+	}
+}
+
 func testCheckRefreshSchedule(scheduleRefreshResourceName string, expectedRefreshSchedule powerbiapi.GetRefreshScheduleInGroupResponse) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		datasetID, err := getResourceProperty(s, scheduleRefreshResourceName, "dataset_id")
@@ -0,0 +1,191 @@
+package powerbi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/codecutout/terraform-provider-powerbi/internal/powerbiapi"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// ResourceReport represents a Power BI report created by cloning an existing report
+func ResourceReport() *schema.Resource {
+	return &schema.Resource{
+		Create: createReport,
+		Read:   readReport,
+		Update: updateReport,
+		Delete: deleteReport,
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				client := meta.(*powerbiapi.Client)
+				idParts := strings.Split(d.Id(), "/")
+				if len(idParts) != 2 {
+					return nil, fmt.Errorf("invalid import ID, expected format: workspace_id/report_id or workspace_name/report_name")
+				}
+				groupID, err := resolveImportWorkspaceID(client, idParts[0])
+				if err != nil {
+					return nil, err
+				}
+				reportID, err := resolveImportReportID(client, groupID, idParts[1], "PowerBIReport")
+				if err != nil {
+					return nil, err
+				}
+				d.Set("workspace_id", groupID)
+				d.SetId(reportID)
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"workspace_id": {
+				Type:        schema.TypeString,
+				Description: "Workspace ID in which the report will be created.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Name of the report.",
+				Required:    true,
+			},
+			"source_report_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the report to clone. Changing this replaces the content of the report with the content of the new source report.",
+				Required:    true,
+			},
+			"source_workspace_id": {
+				Type:        schema.TypeString,
+				Description: "Workspace ID in which the source report exists. Defaults to `workspace_id`.",
+				Optional:    true,
+			},
+			"source_hash": {
+				Type:        schema.TypeString,
+				Description: "Used to trigger the content of the report to be updated from the source report. Any change to this value will copy the content of the source report again.",
+				Optional:    true,
+			},
+			"dataset_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the dataset the report is bound to. If not set the report is bound to the dataset of the source report. Changing this rebinds the report.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"report_type": {
+				Type:        schema.TypeString,
+				Description: "The type of the report.",
+				Computed:    true,
+			},
+			"web_url": {
+				Type:        schema.TypeString,
+				Description: "Web URL of the report.",
+				Computed:    true,
+			},
+			"embed_url": {
+				Type:        schema.TypeString,
+				Description: "Embed URL of the report.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func createReport(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+	sourceGroupID := getSourceWorkspaceID(d)
+
+	request := powerbiapi.CloneReportInGroupRequest{
+		Name:          d.Get("name").(string),
+		TargetModelID: d.Get("dataset_id").(string),
+	}
+	if !strings.EqualFold(groupID, sourceGroupID) {
+		request.TargetWorkspaceID = groupID
+	}
+
+	report, err := client.CloneReportInGroup(sourceGroupID, d.Get("source_report_id").(string), request)
+	if err != nil {
+		return fmt.Errorf("failed to clone report: %w", err)
+	}
+
+	d.SetId(report.ID)
+
+	return readReport(d, meta)
+}
+
+func readReport(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	report, err := client.GetReportInGroup(d.Get("workspace_id").(string), d.Id())
+	if powerbiapi.IsHTTP404Error(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	d.Set("name", report.Name)
+	d.Set("dataset_id", report.DatasetID)
+	d.Set("report_type", report.ReportType)
+	d.Set("web_url", report.WebURL)
+	d.Set("embed_url", report.EmbedURL)
+
+	return nil
+}
+
+func updateReport(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+
+	// content is updated first as the content update keeps the existing dataset binding
+	if d.HasChange("source_report_id") || d.HasChange("source_workspace_id") || d.HasChange("source_hash") {
+		_, err := client.UpdateReportContentInGroup(groupID, d.Id(), powerbiapi.UpdateReportContentInGroupRequest{
+			SourceType: "ExistingReport",
+			SourceReport: powerbiapi.UpdateReportContentInGroupRequestSourceReport{
+				SourceReportID:    d.Get("source_report_id").(string),
+				SourceWorkspaceID: getSourceWorkspaceID(d),
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update report content: %w", err)
+		}
+	}
+
+	if d.HasChange("dataset_id") {
+		err := client.RebindReportInGroup(groupID, d.Id(), powerbiapi.RebindReportInGroupRequest{
+			DatasetID: d.Get("dataset_id").(string),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to rebind report: %w", err)
+		}
+	}
+
+	if d.HasChange("name") {
+		err := client.UpdateReportInGroup(groupID, d.Id(), powerbiapi.UpdateReportInGroupRequest{
+			DisplayName: d.Get("name").(string),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to rename report: %w", err)
+		}
+	}
+
+	return readReport(d, meta)
+}
+
+func deleteReport(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	err := client.DeleteReportInGroup(d.Get("workspace_id").(string), d.Id())
+	if powerbiapi.IsHTTP404Error(err) {
+		return nil
+	}
+	return err
+}
+
+func getSourceWorkspaceID(d *schema.ResourceData) string {
+	if sourceGroupID, ok := d.GetOk("source_workspace_id"); ok {
+		return sourceGroupID.(string)
+	}
+	return d.Get("workspace_id").(string)
+}
@@ -0,0 +1,305 @@
+package powerbi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/codecutout/terraform-provider-powerbi/internal/powerbiapi"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// ResourceReportExport represents a Power BI report exported to a local file
+func ResourceReportExport() *schema.Resource {
+	return &schema.Resource{
+		Create: createReportExport,
+		Read:   readReportExport,
+		Delete: deleteReportExport,
+
+		Schema: map[string]*schema.Schema{
+			"workspace_id": {
+				Type:        schema.TypeString,
+				Description: "Workspace ID in which the report exists.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"report_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the report to export.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"format": {
+				Type:        schema.TypeString,
+				Description: "The format of the exported file. Options: `PDF`, `PPTX`, `PNG`, `PBIX`.",
+				Required:    true,
+				ForceNew:    true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"PDF", "PPTX", "PNG", "PBIX",
+				}, false),
+			},
+			"output_path": {
+				Type:        schema.TypeString,
+				Description: "The local path the exported file will be written to. `PNG` exports of multiple pages are written as a zip file.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"page": {
+				Type:        schema.TypeList,
+				Description: "Pages to export. If not set all pages are exported. Not supported for `PBIX`.",
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The page name. This is the internal name of the page, not the display name",
+							Required:    true,
+							ForceNew:    true,
+						},
+						"bookmark_name": {
+							Type:        schema.TypeString,
+							Description: "The name of a bookmark to apply to the page",
+							Optional:    true,
+							ForceNew:    true,
+						},
+					},
+				},
+			},
+			"bookmark_name": {
+				Type:        schema.TypeString,
+				Description: "The name of a bookmark to apply to all pages. Not supported for `PBIX`.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"filters": {
+				Type:        schema.TypeList,
+				Description: "Report level filters to apply, in OData filter syntax. For example `Store/Territory eq 'NC'`. Not supported for `PBIX`.",
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"identity": {
+				Type:        schema.TypeList,
+				Description: "Effective identities used to apply row level security. Not supported for `PBIX`.",
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"username": {
+							Type:        schema.TypeString,
+							Description: "The effective username",
+							Required:    true,
+							ForceNew:    true,
+						},
+						"roles": {
+							Type:        schema.TypeList,
+							Description: "The row level security roles to apply",
+							Optional:    true,
+							ForceNew:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"dataset_ids": {
+							Type:        schema.TypeList,
+							Description: "The datasets the identity applies to",
+							Optional:    true,
+							ForceNew:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"locale": {
+				Type:        schema.TypeString,
+				Description: "The locale to apply when exporting. For example `en-US`. Not supported for `PBIX`.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"include_hidden_pages": {
+				Type:        schema.TypeBool,
+				Description: "If true, hidden pages are included in the export. Not supported for `PBIX`.",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Description: "Arbitrary values that cause the report to be exported again when changed. For example a date to export monthly.",
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"output_hash": {
+				Type:        schema.TypeString,
+				Description: "The SHA-256 hash of the exported file. If the file is modified or removed the report will be exported again.",
+				Computed:    true,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(10 * time.Minute),
+		},
+	}
+}
+
+func createReportExport(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*powerbiapi.Client)
+
+	groupID := d.Get("workspace_id").(string)
+	reportID := d.Get("report_id").(string)
+	format := d.Get("format").(string)
+
+	var content io.ReadCloser
+	var err error
+	if format == "PBIX" {
+		content, err = client.ExportReportPBIXInGroup(groupID, reportID)
+		if err != nil {
+			return fmt.Errorf("failed to export report: %w", err)
+		}
+	} else {
+		export, err := client.ExportReportInGroup(groupID, reportID, powerbiapi.ExportReportInGroupRequest{
+			Format:                     format,
+			PowerBIReportConfiguration: buildPowerBIReportExportConfiguration(d),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to export report: %w", err)
+		}
+
+		_, err = client.WaitForExportReportInGroupToSucceed(groupID, reportID, export.ID, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return err
+		}
+
+		content, err = client.GetExportReportFileInGroup(groupID, reportID, export.ID)
+		if err != nil {
+			return fmt.Errorf("failed to download exported report: %w", err)
+		}
+	}
+	defer content.Close()
+
+	hash, err := writeExportFile(d.Get("output_path").(string), content)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(hash)
+	d.Set("output_hash", hash)
+
+	return readReportExport(d, meta)
+}
+
+func readReportExport(d *schema.ResourceData, meta interface{}) error {
+	outputPath := d.Get("output_path").(string)
+
+	// the exported file is the only thing we manage, so export again if it has changed
+	hash, err := sha256File(outputPath)
+	if os.IsNotExist(err) {
+		log.Printf("[INFO] Exported report %s no longer exists, it will be exported again", outputPath)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if hash != d.Get("output_hash").(string) {
+		log.Printf("[INFO] Exported report %s has been modified, it will be exported again", outputPath)
+		d.SetId("")
+		return nil
+	}
+
+	return nil
+}
+
+func deleteReportExport(d *schema.ResourceData, meta interface{}) error {
+	err := os.Remove(d.Get("output_path").(string))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func buildPowerBIReportExportConfiguration(d *schema.ResourceData) *powerbiapi.PowerBIReportExportConfiguration {
+	configuration := powerbiapi.PowerBIReportExportConfiguration{}
+
+	for _, pageObj := range d.Get("page").([]interface{}) {
+		page := pageObj.(map[string]interface{})
+		exportPage := powerbiapi.ExportReportPage{
+			PageName: page["name"].(string),
+		}
+		if bookmarkName := page["bookmark_name"].(string); bookmarkName != "" {
+			exportPage.Bookmark = &powerbiapi.PageBookmark{Name: bookmarkName}
+		}
+		configuration.Pages = append(configuration.Pages, exportPage)
+	}
+
+	if bookmarkName, ok := d.GetOk("bookmark_name"); ok {
+		configuration.DefaultBookmark = &powerbiapi.PageBookmark{Name: bookmarkName.(string)}
+	}
+
+	for _, filter := range d.Get("filters").([]interface{}) {
+		configuration.ReportLevelFilters = append(configuration.ReportLevelFilters, powerbiapi.ExportFilter{
+			Filter: filter.(string),
+		})
+	}
+
+	for _, identityObj := range d.Get("identity").([]interface{}) {
+		identity := identityObj.(map[string]interface{})
+		configuration.Identities = append(configuration.Identities, powerbiapi.EffectiveIdentity{
+			Username: identity["username"].(string),
+			Roles:    convertToStringSlice(identity["roles"].([]interface{})),
+			Datasets: convertToStringSlice(identity["dataset_ids"].([]interface{})),
+		})
+	}
+
+	locale := d.Get("locale").(string)
+	includeHiddenPages := d.Get("include_hidden_pages").(bool)
+	if locale != "" || includeHiddenPages {
+		configuration.Settings = &powerbiapi.ExportReportSettings{
+			Locale:             locale,
+			IncludeHiddenPages: includeHiddenPages,
+		}
+	}
+
+	return &configuration
+}
+
+// writeExportFile writes content to a temporary file alongside outputPath and renames it into place,
+// so a failed download never leaves a partial file. The SHA-256 hash of the content is returned
+func writeExportFile(outputPath string, content io.Reader) (string, error) {
+	tempFile, err := ioutil.TempFile(filepath.Dir(outputPath), filepath.Base(outputPath)+".*.tmp")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tempFile.Name())
+
+	hasher := sha256.New()
+	_, err = io.Copy(io.MultiWriter(tempFile, hasher), content)
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to write exported report to %s: %w", outputPath, err)
+	}
+
+	err = os.Rename(tempFile.Name(), outputPath)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
@@ -0,0 +1,128 @@
+package powerbi
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccReportExport_basic(t *testing.T) {
+	var firstHash string
+	workspaceSuffix := acctest.RandString(6)
+	outputPath := TempFileName("", ".pdf")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerbiWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			// first step exports the report to a file
+			{
+				Config: testAccReportExportConfig(workspaceSuffix, outputPath, "2026-01"),
+				Check: resource.ComposeTestCheckFunc(
+					set("powerbi_report_export.test", "output_hash", &firstHash),
+					testCheckExportFileHash("powerbi_report_export.test", outputPath),
+				),
+			},
+			// changing triggers exports again
+			{
+				Config: testAccReportExportConfig(workspaceSuffix, outputPath, "2026-02"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckExportFileHash("powerbi_report_export.test", outputPath),
+				),
+			},
+			// removing the file exports again
+			{
+				PreConfig: func() {
+					os.Remove(outputPath)
+				},
+				Config: testAccReportExportConfig(workspaceSuffix, outputPath, "2026-02"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckExportFileHash("powerbi_report_export.test", outputPath),
+				),
+			},
+		},
+	})
+}
+
+func TestWriteExportFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "report_export")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	outputPath := filepath.Join(dir, "report.pdf")
+	hash, err := writeExportFile(outputPath, strings.NewReader("content"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// echo -n "content" | sha256sum
+	expected := "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73"
+	if hash != expected {
+		t.Errorf("expected %s, got %s", expected, hash)
+	}
+
+	fileHash, err := sha256File(outputPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fileHash != expected {
+		t.Errorf("expected file hash %s, got %s", expected, fileHash)
+	}
+
+	files, _ := ioutil.ReadDir(dir)
+	if len(files) != 1 {
+		t.Errorf("expected temporary file to be removed, found %d files", len(files))
+	}
+}
+
+func testCheckExportFileHash(resourceName string, outputPath string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		expected, err := getResourceProperty(s, resourceName, "output_hash")
+		if err != nil {
+			return err
+		}
+		actual, err := sha256File(outputPath)
+		if err != nil {
+			return err
+		}
+		if actual != expected {
+			return fmt.Errorf("expected exported file hash %s, got %s", expected, actual)
+		}
+		return nil
+	}
+}
+
+func testAccReportExportConfig(workspaceSuffix string, outputPath string, period string) string {
+	return fmt.Sprintf(`
+	resource "powerbi_workspace" "test" {
+		name = "Acceptance Test Workspace %s"
+	}
+
+	resource "powerbi_pbix" "test" {
+		workspace_id = "${powerbi_workspace.test.id}"
+		name = "Acceptance Test PBIX"
+		source = "./resource_pbix_test_sample1.pbix"
+		source_hash = "${filemd5("./resource_pbix_test_sample1.pbix")}"
+	}
+
+	resource "powerbi_report_export" "test" {
+		workspace_id = "${powerbi_workspace.test.id}"
+		report_id = "${powerbi_pbix.test.report_id}"
+		format = "PDF"
+		output_path = "%s"
+		triggers = {
+			period = "%s"
+		}
+	}
+	`, workspaceSuffix, filepath.ToSlash(outputPath), period)
+}
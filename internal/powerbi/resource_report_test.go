@@ -0,0 +1,92 @@
+package powerbi
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccReport_basic(t *testing.T) {
+	var reportID string
+	workspaceSuffix := acctest.RandString(6)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckPowerbiWorkspaceDestroy,
+		Steps: []resource.TestStep{
+			// first step clones the report of the first pbix
+			{
+				Config: testAccReportConfig(workspaceSuffix, "Acceptance Test Report", "sample1", "sample1"),
+				Check: resource.ComposeTestCheckFunc(
+					set("powerbi_report.test", "id", &reportID),
+					resource.TestCheckResourceAttr("powerbi_report.test", "name", "Acceptance Test Report"),
+					resource.TestCheckResourceAttrPair("powerbi_report.test", "dataset_id", "powerbi_pbix.sample1", "dataset_id"),
+					resource.TestCheckResourceAttrSet("powerbi_report.test", "web_url"),
+				),
+			},
+			// second step renames and rebinds the same report
+			{
+				Config: testAccReportConfig(workspaceSuffix, "Acceptance Test Report Renamed", "sample1", "sample2"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckResourceAttrUnchanged("powerbi_report.test", "id", &reportID),
+					resource.TestCheckResourceAttr("powerbi_report.test", "name", "Acceptance Test Report Renamed"),
+					resource.TestCheckResourceAttrPair("powerbi_report.test", "dataset_id", "powerbi_pbix.sample2", "dataset_id"),
+				),
+			},
+			// third step updates content from another report while keeping the binding
+			{
+				Config: testAccReportConfig(workspaceSuffix, "Acceptance Test Report Renamed", "sample2", "sample2"),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckResourceAttrUnchanged("powerbi_report.test", "id", &reportID),
+					resource.TestCheckResourceAttrPair("powerbi_report.test", "dataset_id", "powerbi_pbix.sample2", "dataset_id"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckResourceAttrUnchanged(resourceName string, attribute string, expected *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		actual, err := getResourceProperty(s, resourceName, attribute)
+		if err != nil {
+			return err
+		}
+		if actual != *expected {
+			return fmt.Errorf("expected %s %s to remain %s but was %s", resourceName, attribute, *expected, actual)
+		}
+		return nil
+	}
+}
+
+func testAccReportConfig(workspaceSuffix string, name string, source string, dataset string) string {
+	return fmt.Sprintf(`
+	resource "powerbi_workspace" "test" {
+		name = "Acceptance Test Workspace %s"
+	}
+
+	resource "powerbi_pbix" "sample1" {
+		workspace_id = "${powerbi_workspace.test.id}"
+		name = "Acceptance Test PBIX 1"
+		source = "./resource_pbix_test_sample1.pbix"
+		source_hash = "${filemd5("./resource_pbix_test_sample1.pbix")}"
+	}
+
+	resource "powerbi_pbix" "sample2" {
+		workspace_id = "${powerbi_workspace.test.id}"
+		name = "Acceptance Test PBIX 2"
+		source = "./resource_pbix_test_sample2.pbix"
+		source_hash = "${filemd5("./resource_pbix_test_sample2.pbix")}"
+	}
+
+	resource "powerbi_report" "test" {
+		workspace_id = "${powerbi_workspace.test.id}"
+		name = "%s"
+		source_report_id = "${powerbi_pbix.%s.report_id}"
+		dataset_id = "${powerbi_pbix.%s.dataset_id}"
+	}
+	`, workspaceSuffix, name, source, dataset)
+}
@@ -16,7 +16,15 @@ func ResourceWorkspace() *schema.Resource {
 		Update: updateWorkspace,
 		Delete: deleteWorkspace,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				client := meta.(*powerbiapi.Client)
+				groupID, err := resolveImportWorkspaceID(client, d.Id())
+				if err != nil {
+					return nil, err
+				}
+				d.SetId(groupID)
+				return []*schema.ResourceData{d}, nil
+			},
 		},
 
 		Schema: map[string]*schema.Schema{
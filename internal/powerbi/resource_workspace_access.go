@@ -19,7 +19,27 @@ func ResourceGroupUsers() *schema.Resource {
 		Update: updateGroupUser,
 		Delete: deleteGroupUser,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				client := meta.(*powerbiapi.Client)
+				idParts := strings.SplitN(d.Id(), "/", 2)
+				if len(idParts) != 2 {
+					return nil, fmt.Errorf("invalid import ID, expected format: workspace_name/identifier or workspace_id/identifier")
+				}
+				groupID, err := resolveImportWorkspaceID(client, idParts[0])
+				if err != nil {
+					return nil, err
+				}
+				workspaceObj, err := client.GetGroup(groupID)
+				if err != nil {
+					return nil, err
+				}
+				if workspaceObj == nil {
+					return nil, fmt.Errorf("workspace %s not found", idParts[0])
+				}
+				d.Set("workspace_id", groupID)
+				d.SetId(fmt.Sprintf("%s/%s", workspaceObj.Name, idParts[1]))
+				return []*schema.ResourceData{d}, nil
+			},
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -1,10 +1,11 @@
 package powerbi
 
 import (
-	"net/url"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
 	"reflect"
-
-	"github.com/codecutout/terraform-provider-powerbi/internal/powerbiapi"
 )
 
 func convertStringToPointer(s string) *string {
@@ -41,33 +42,25 @@ func emptyStringToNil(input string) *string {
 	return &input
 }
 
-func isHTTP404Error(err error) bool {
-	if httpErr, isHTTPErr := toHTTPUnsuccessfulError(err); isHTTPErr && httpErr.Response.StatusCode == 404 {
-		return true
-	}
-	return false
-}
-
-func isHTTP401Error(err error) bool {
-	if httpErr, isHTTPErr := toHTTPUnsuccessfulError(err); isHTTPErr && httpErr.Response.StatusCode == 401 {
-		return true
+func nilToEmptyString(input *string) string {
+	if input == nil {
+		return ""
 	}
-	return false
+	return *input
 }
 
-func toHTTPUnsuccessfulError(err error) (*powerbiapi.HTTPUnsuccessfulError, bool) {
-	if err == nil {
-		return nil, false
-	}
-
-	if urlErr, isURLErr := err.(*url.Error); isURLErr {
-		err = urlErr.Unwrap()
+func sha256File(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
 	}
+	defer file.Close()
 
-	if httpErr, isHTTPErr := err.(powerbiapi.HTTPUnsuccessfulError); isHTTPErr {
-		return &httpErr, true
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return "", err
 	}
-	return nil, false
+	return hex.EncodeToString(hasher.Sum(nil)), nil
 }
 
 type wrappedError struct {
@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"crypto/tls"
 	"encoding/json"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"mime/multipart"
 	"net/http"
 	"net/textproto"
+	"net/url"
 	"time"
 
 	"github.com/hashicorp/go-cleanhttp"
@@ -129,6 +131,18 @@ func (client *Client) doMultipartJSON(method string, url string, body io.Reader,
 	return newJSONResponse(httpResponse, response)
 }
 
+// fabricURL is the base URL of the Fabric REST API, used where the Power BI API has no equivalent
+const fabricURL = "https://api.fabric.microsoft.com/v1"
+
+// groupURL returns the base URL for requests within a group. An empty groupID
+// refers to "My workspace" of the authenticated user, which has no group
+func groupURL(groupID string) string {
+	if groupID == "" {
+		return "https://api.powerbi.com/v1.0/myorg"
+	}
+	return fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/groups/%s", url.PathEscape(groupID))
+}
+
 func newJSONRequest(method string, url string, body interface{}) (*http.Request, error) {
 
 	// if we have no body so can create a simple request
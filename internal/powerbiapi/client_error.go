@@ -2,6 +2,7 @@ package powerbiapi
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -39,6 +40,21 @@ func (err HTTPUnsuccessfulError) Error() string {
 	return message
 }
 
+// IsHTTP404Error returns true if err is, or wraps, an HTTPUnsuccessfulError with a 404 status code
+func IsHTTP404Error(err error) bool {
+	return isHTTPStatusError(err, http.StatusNotFound)
+}
+
+// IsHTTP401Error returns true if err is, or wraps, an HTTPUnsuccessfulError with a 401 status code
+func IsHTTP401Error(err error) bool {
+	return isHTTPStatusError(err, http.StatusUnauthorized)
+}
+
+func isHTTPStatusError(err error, statusCode int) bool {
+	var httpErr HTTPUnsuccessfulError
+	return errors.As(err, &httpErr) && httpErr.Response.StatusCode == statusCode
+}
+
 func newErrorOnUnsuccessfulRoundTripper(next http.RoundTripper) http.RoundTripper {
 	return &errorOnUnsuccessfulRoundTripper{
 		innerRoundTripper: next,
@@ -0,0 +1,78 @@
+package powerbiapi
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// RefreshDatasetInGroupRequest represents the request to refresh a dataset
+type RefreshDatasetInGroupRequest struct {
+	NotifyOption string `json:"notifyOption"`
+}
+
+// GetRefreshHistoryInGroupResponse represents the refresh history of a dataset
+type GetRefreshHistoryInGroupResponse struct {
+	Value []GetRefreshHistoryInGroupResponseItem
+}
+
+// GetRefreshHistoryInGroupResponseItem represents a single refresh of a dataset
+type GetRefreshHistoryInGroupResponseItem struct {
+	ID                   int64
+	RequestID            string
+	RefreshType          string
+	StartTime            time.Time
+	EndTime              time.Time
+	Status               string
+	ServiceExceptionJSON string
+}
+
+// RefreshDatasetInGroup triggers a refresh of a dataset within a group.
+func (client *Client) RefreshDatasetInGroup(groupID string, datasetID string, request RefreshDatasetInGroupRequest) error {
+
+	url := fmt.Sprintf("%s/datasets/%s/refreshes", groupURL(groupID), url.PathEscape(datasetID))
+	err := client.doJSON("POST", url, request, nil)
+
+	return err
+}
+
+// GetRefreshHistoryInGroup returns the most recent refreshes of a dataset within a group, newest first.
+func (client *Client) GetRefreshHistoryInGroup(groupID string, datasetID string, top int) (*GetRefreshHistoryInGroupResponse, error) {
+
+	var respObj GetRefreshHistoryInGroupResponse
+	url := fmt.Sprintf("%s/datasets/%s/refreshes?$top=%d", groupURL(groupID), url.PathEscape(datasetID), top)
+	err := client.doJSON("GET", url, nil, &respObj)
+
+	return &respObj, err
+}
+
+// WaitForRefreshInGroupToComplete waits until a refresh newer than previousRefreshID completes successfully.
+// A previousRefreshID of 0 waits on the first refresh of the dataset
+func (client *Client) WaitForRefreshInGroupToComplete(groupID string, datasetID string, previousRefreshID int64, timeout time.Duration) (*GetRefreshHistoryInGroupResponseItem, error) {
+	// refreshes take at least several seconds so there is no need to poll as often as imports
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+
+	started := time.Now()
+	for {
+		history, err := client.GetRefreshHistoryInGroup(groupID, datasetID, 1)
+		if err != nil {
+			return nil, err
+		}
+
+		// the refresh may not appear in the history immediately after being triggered
+		if len(history.Value) > 0 && history.Value[0].ID != previousRefreshID {
+			refresh := history.Value[0]
+			if refresh.Status == "Completed" {
+				return &refresh, nil
+			} else if refresh.Status != "Unknown" && refresh.Status != "NotStarted" {
+				return &refresh, fmt.Errorf("Refresh completed with invalid state '%s': %s", refresh.Status, refresh.ServiceExceptionJSON)
+			}
+		}
+
+		now := <-ticker.C
+		if now.Sub(started) > timeout {
+			return nil, fmt.Errorf("Timed out waiting for refresh to complete. Refresh taking longer than %v seconds", timeout.Seconds())
+		}
+	}
+}
@@ -15,6 +15,8 @@ type GetDatasetInGroupResponse struct {
 	IsEffectiveIdentityRequired      bool
 	IsEffectiveIdentityRolesRequired bool
 	TargetStorageMode                string
+	WebURL                           string
+	CreateReportEmbedURL             string
 }
 
 // GetDatasetsInGroupResponse represents the details when getting a datasets in a group.
@@ -32,6 +34,8 @@ type GetDatasetsInGroupResponseItem struct {
 	IsEffectiveIdentityRequired      bool
 	IsEffectiveIdentityRolesRequired bool
 	TargetStorageMode                string
+	WebURL                           string
+	CreateReportEmbedURL             string
 }
 
 // GetParametersInGroupResponse represents the response from get parameters
@@ -113,6 +117,50 @@ type GetRefreshScheduleInGroupResponse struct {
 	NotifyOption    string
 }
 
+// GetUpstreamDataflowsInGroupResponse represents the links between datasets and dataflows in a group
+type GetUpstreamDataflowsInGroupResponse struct {
+	Value []GetUpstreamDataflowsInGroupResponseItem
+}
+
+// GetUpstreamDataflowsInGroupResponseItem represents a dataset that uses a dataflow
+type GetUpstreamDataflowsInGroupResponseItem struct {
+	DatasetObjectID   string
+	DataflowObjectID  string
+	WorkspaceObjectID string
+}
+
+// ExecuteQueriesInGroupRequest represents the request to execute DAX queries against a dataset
+type ExecuteQueriesInGroupRequest struct {
+	Queries []ExecuteQueriesInGroupRequestQuery `json:"queries"`
+}
+
+// ExecuteQueriesInGroupRequestQuery represents a single DAX query
+type ExecuteQueriesInGroupRequestQuery struct {
+	Query string `json:"query"`
+}
+
+// ExecuteQueriesInGroupResponse represents the results of executing DAX queries
+type ExecuteQueriesInGroupResponse struct {
+	Results []ExecuteQueriesInGroupResponseResult
+}
+
+// ExecuteQueriesInGroupResponseResult represents the result of a single DAX query
+type ExecuteQueriesInGroupResponseResult struct {
+	Tables []ExecuteQueriesInGroupResponseTable
+	Error  *ExecuteQueriesInGroupResponseError
+}
+
+// ExecuteQueriesInGroupResponseTable represents a table returned by a DAX query
+type ExecuteQueriesInGroupResponseTable struct {
+	Rows []map[string]interface{}
+}
+
+// ExecuteQueriesInGroupResponseError represents an error returned by a DAX query
+type ExecuteQueriesInGroupResponseError struct {
+	Code    string
+	Message string
+}
+
 // UpdateRefreshScheduleInGroupRequest represents the request to update refresh schedules
 type UpdateRefreshScheduleInGroupRequest struct {
 	Value UpdateRefreshScheduleInGroupRequestValue `json:"value"`
@@ -127,11 +175,17 @@ type UpdateRefreshScheduleInGroupRequestValue struct {
 	NotifyOption    *string   `json:"notifyOption,omitempty"`
 }
 
+// BindToGatewayInGroupRequest represents the request to bind a dataset to a gateway
+type BindToGatewayInGroupRequest struct {
+	GatewayObjectID     string   `json:"gatewayObjectId"`
+	DatasourceObjectIDs []string `json:"datasourceObjectIds,omitempty"`
+}
+
 // GetDatasetInGroup returns a dataset within the specified group.
 func (client *Client) GetDatasetInGroup(groupID string, datasetID string) (*GetDatasetInGroupResponse, error) {
 
 	var respObj GetDatasetInGroupResponse
-	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/groups/%s/datasets/%s", url.PathEscape(groupID), url.PathEscape(datasetID))
+	url := fmt.Sprintf("%s/datasets/%s", groupURL(groupID), url.PathEscape(datasetID))
 	err := client.doJSON("GET", url, nil, &respObj)
 
 	return &respObj, err
@@ -141,16 +195,25 @@ func (client *Client) GetDatasetInGroup(groupID string, datasetID string) (*GetD
 func (client *Client) GetDatasetsInGroup(groupID string) (*GetDatasetsInGroupResponse, error) {
 
 	var respObj GetDatasetsInGroupResponse
-	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/groups/%s/datasets", url.PathEscape(groupID))
+	url := fmt.Sprintf("%s/datasets", groupURL(groupID))
 	err := client.doJSON("GET", url, nil, &respObj)
 
 	return &respObj, err
 }
 
+// TakeOverInGroup transfers ownership of a dataset within a group to the current authorized user.
+func (client *Client) TakeOverInGroup(groupID string, datasetID string) error {
+
+	url := fmt.Sprintf("%s/datasets/%s/Default.TakeOver", groupURL(groupID), url.PathEscape(datasetID))
+	err := client.doJSON("POST", url, nil, nil)
+
+	return err
+}
+
 // DeleteDatasetInGroup deletes a dataset that exists within a group.
 func (client *Client) DeleteDatasetInGroup(groupID string, datasetID string) error {
 
-	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/groups/%s/datasets/%s", url.PathEscape(groupID), url.PathEscape(datasetID))
+	url := fmt.Sprintf("%s/datasets/%s", groupURL(groupID), url.PathEscape(datasetID))
 	err := client.doJSON("DELETE", url, nil, nil)
 
 	return err
@@ -160,7 +223,7 @@ func (client *Client) DeleteDatasetInGroup(groupID string, datasetID string) err
 func (client *Client) GetParametersInGroup(groupID string, datasetID string) (*GetParametersInGroupResponse, error) {
 
 	var respObj GetParametersInGroupResponse
-	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/groups/%s/datasets/%s/parameters", url.PathEscape(groupID), url.PathEscape(datasetID))
+	url := fmt.Sprintf("%s/datasets/%s/parameters", groupURL(groupID), url.PathEscape(datasetID))
 	err := client.doJSON("GET", url, nil, &respObj)
 
 	return &respObj, err
@@ -169,7 +232,7 @@ func (client *Client) GetParametersInGroup(groupID string, datasetID string) (*G
 // UpdateParametersInGroup updates parameters in a dataset that exists within a group.
 func (client *Client) UpdateParametersInGroup(groupID string, datasetID string, request UpdateParametersInGroupRequest) error {
 
-	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/groups/%s/datasets/%s/Default.UpdateParameters", url.PathEscape(groupID), url.PathEscape(datasetID))
+	url := fmt.Sprintf("%s/datasets/%s/Default.UpdateParameters", groupURL(groupID), url.PathEscape(datasetID))
 	err := client.doJSON("POST", url, &request, nil)
 
 	return err
@@ -179,7 +242,7 @@ func (client *Client) UpdateParametersInGroup(groupID string, datasetID string,
 func (client *Client) GetDatasourcesInGroup(groupID string, datasetID string) (*GetDatasourcesInGroupResponse, error) {
 
 	var respObj GetDatasourcesInGroupResponse
-	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/groups/%s/datasets/%s/datasources", url.PathEscape(groupID), url.PathEscape(datasetID))
+	url := fmt.Sprintf("%s/datasets/%s/datasources", groupURL(groupID), url.PathEscape(datasetID))
 	err := client.doJSON("GET", url, nil, &respObj)
 
 	return &respObj, err
@@ -188,7 +251,7 @@ func (client *Client) GetDatasourcesInGroup(groupID string, datasetID string) (*
 // UpdateDatasourcesInGroup updates datasources in a dataset that exists within a group.
 func (client *Client) UpdateDatasourcesInGroup(groupID string, datasetID string, request UpdateDatasourcesInGroupRequest) error {
 
-	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/groups/%s/datasets/%s/Default.UpdateDatasources", url.PathEscape(groupID), url.PathEscape(datasetID))
+	url := fmt.Sprintf("%s/datasets/%s/Default.UpdateDatasources", groupURL(groupID), url.PathEscape(datasetID))
 	err := client.doJSON("POST", url, &request, nil)
 
 	return err
@@ -198,7 +261,7 @@ func (client *Client) UpdateDatasourcesInGroup(groupID string, datasetID string,
 func (client *Client) GetRefreshScheduleInGroup(groupID string, datasetID string) (*GetRefreshScheduleInGroupResponse, error) {
 
 	var respObj GetRefreshScheduleInGroupResponse
-	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/groups/%s/datasets/%s/refreshSchedule", url.PathEscape(groupID), url.PathEscape(datasetID))
+	url := fmt.Sprintf("%s/datasets/%s/refreshSchedule", groupURL(groupID), url.PathEscape(datasetID))
 	err := client.doJSON("GET", url, nil, &respObj)
 
 	return &respObj, err
@@ -207,8 +270,47 @@ func (client *Client) GetRefreshScheduleInGroup(groupID string, datasetID string
 // UpdateRefreshScheduleInGroup updates a datasource's refresh schedule.
 func (client *Client) UpdateRefreshScheduleInGroup(groupID string, datasetID string, request UpdateRefreshScheduleInGroupRequest) error {
 
-	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/groups/%s/datasets/%s/refreshSchedule", url.PathEscape(groupID), url.PathEscape(datasetID))
+	url := fmt.Sprintf("%s/datasets/%s/refreshSchedule", groupURL(groupID), url.PathEscape(datasetID))
 	err := client.doJSON("PATCH", url, &request, nil)
 
 	return err
 }
+
+// BindToGatewayInGroup binds a dataset within a group to the specified gateway.
+func (client *Client) BindToGatewayInGroup(groupID string, datasetID string, request BindToGatewayInGroupRequest) error {
+
+	url := fmt.Sprintf("%s/datasets/%s/Default.BindToGateway", groupURL(groupID), url.PathEscape(datasetID))
+	err := client.doJSON("POST", url, &request, nil)
+
+	return err
+}
+
+// DiscoverGatewaysInGroup returns the gateways a dataset within a group can be bound to.
+func (client *Client) DiscoverGatewaysInGroup(groupID string, datasetID string) (*GetGatewaysResponse, error) {
+
+	var respObj GetGatewaysResponse
+	url := fmt.Sprintf("%s/datasets/%s/Default.DiscoverGateways", groupURL(groupID), url.PathEscape(datasetID))
+	err := client.doJSON("GET", url, nil, &respObj)
+
+	return &respObj, err
+}
+
+// GetUpstreamDataflowsInGroup returns the dataflows used by each dataset within a group.
+func (client *Client) GetUpstreamDataflowsInGroup(groupID string) (*GetUpstreamDataflowsInGroupResponse, error) {
+
+	var respObj GetUpstreamDataflowsInGroupResponse
+	url := fmt.Sprintf("%s/datasets/upstreamDataflows", groupURL(groupID))
+	err := client.doJSON("GET", url, nil, &respObj)
+
+	return &respObj, err
+}
+
+// ExecuteQueriesInGroup executes DAX queries against a dataset within a group.
+func (client *Client) ExecuteQueriesInGroup(groupID string, datasetID string, request ExecuteQueriesInGroupRequest) (*ExecuteQueriesInGroupResponse, error) {
+
+	var respObj ExecuteQueriesInGroupResponse
+	url := fmt.Sprintf("%s/datasets/%s/executeQueries", groupURL(groupID), url.PathEscape(datasetID))
+	err := client.doJSON("POST", url, request, &respObj)
+
+	return &respObj, err
+}
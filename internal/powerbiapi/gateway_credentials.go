@@ -0,0 +1,196 @@
+package powerbiapi
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+const (
+	// legacyModulusLength is the modulus size in bytes of gateways using 1024 bit keys
+	legacyModulusLength = 128
+	// legacySegmentLength is the size of each plaintext segment when encrypting with a 1024 bit key
+	legacySegmentLength = 85
+
+	aesKeyLength  = 32
+	hmacKeyLength = 64
+
+	// key length identifiers prefixed to the ephemeral keys
+	aesKeyLength32Identifier  = 0
+	hmacKeyLength64Identifier = 1
+
+	// algorithm identifiers prefixed to the ciphertext
+	aes256CbcPkcs7Identifier = 0
+	hmacSha256Identifier     = 0
+)
+
+// CredentialData represents the credentials of a datasource before they are encrypted
+type CredentialData struct {
+	CredentialData []CredentialDataItem `json:"credentialData"`
+}
+
+// CredentialDataItem represents a single named credential value
+type CredentialDataItem struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// BuildBasicCredentials returns the credentials string for Basic credentials
+func BuildBasicCredentials(username string, password string) (string, error) {
+	return buildCredentials(CredentialDataItem{Name: "username", Value: username}, CredentialDataItem{Name: "password", Value: password})
+}
+
+// BuildWindowsCredentials returns the credentials string for Windows credentials
+func BuildWindowsCredentials(username string, password string) (string, error) {
+	return buildCredentials(CredentialDataItem{Name: "username", Value: username}, CredentialDataItem{Name: "password", Value: password})
+}
+
+// BuildKeyCredentials returns the credentials string for Key credentials
+func BuildKeyCredentials(key string) (string, error) {
+	return buildCredentials(CredentialDataItem{Name: "key", Value: key})
+}
+
+// BuildOAuth2Credentials returns the credentials string for OAuth2 credentials
+func BuildOAuth2Credentials(accessToken string) (string, error) {
+	return buildCredentials(CredentialDataItem{Name: "accessToken", Value: accessToken})
+}
+
+// BuildAnonymousCredentials returns the credentials string for Anonymous credentials
+func BuildAnonymousCredentials() string {
+	return `{"credentialData":""}`
+}
+
+func buildCredentials(items ...CredentialDataItem) (string, error) {
+	credentials, err := json.Marshal(CredentialData{CredentialData: items})
+	if err != nil {
+		return "", err
+	}
+	return string(credentials), nil
+}
+
+// EncryptCredentials encrypts credentials with the public key of a gateway, in the same way as the Power BI SDKs.
+// Gateways with 1024 bit keys use RSA-OAEP directly. Larger keys use RSA-OAEP to encrypt ephemeral
+// AES-256-CBC and HMAC-SHA256 keys which are used to encrypt and authenticate the credentials
+func EncryptCredentials(credentials string, publicKey GatewayPublicKey) (string, error) {
+	return encryptCredentials(rand.Reader, credentials, publicKey)
+}
+
+func encryptCredentials(random io.Reader, credentials string, publicKey GatewayPublicKey) (string, error) {
+	rsaPublicKey, err := parseGatewayPublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+
+	if rsaPublicKey.Size() == legacyModulusLength {
+		return encryptCredentialsWithLegacyKey(random, rsaPublicKey, []byte(credentials))
+	}
+	return encryptCredentialsWithHigherKey(random, rsaPublicKey, []byte(credentials))
+}
+
+func parseGatewayPublicKey(publicKey GatewayPublicKey) (*rsa.PublicKey, error) {
+	modulus, err := base64.StdEncoding.DecodeString(publicKey.Modulus)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gateway public key modulus: %w", err)
+	}
+	exponent, err := base64.StdEncoding.DecodeString(publicKey.Exponent)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gateway public key exponent: %w", err)
+	}
+	if len(modulus) == 0 || len(exponent) == 0 {
+		return nil, fmt.Errorf("gateway public key is empty")
+	}
+
+	e := new(big.Int).SetBytes(exponent)
+	if !e.IsInt64() || e.Int64() > int64(^uint32(0)>>1) {
+		return nil, fmt.Errorf("gateway public key exponent is too large")
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(modulus),
+		E: int(e.Int64()),
+	}, nil
+}
+
+func encryptCredentialsWithLegacyKey(random io.Reader, publicKey *rsa.PublicKey, plaintext []byte) (string, error) {
+	var encrypted bytes.Buffer
+	for start := 0; start < len(plaintext); start += legacySegmentLength {
+		end := start + legacySegmentLength
+		if end > len(plaintext) {
+			end = len(plaintext)
+		}
+
+		segment, err := rsa.EncryptOAEP(sha1.New(), random, publicKey, plaintext[start:end], nil)
+		if err != nil {
+			return "", err
+		}
+		encrypted.Write(segment)
+	}
+	return base64.StdEncoding.EncodeToString(encrypted.Bytes()), nil
+}
+
+func encryptCredentialsWithHigherKey(random io.Reader, publicKey *rsa.PublicKey, plaintext []byte) (string, error) {
+	keys := make([]byte, 2+aesKeyLength+hmacKeyLength)
+	keys[0] = aesKeyLength32Identifier
+	keys[1] = hmacKeyLength64Identifier
+	if _, err := io.ReadFull(random, keys[2:]); err != nil {
+		return "", err
+	}
+	keyEnc := keys[2 : 2+aesKeyLength]
+	keyMac := keys[2+aesKeyLength:]
+
+	iv := make([]byte, aes.BlockSize)
+	if _, err := io.ReadFull(random, iv); err != nil {
+		return "", err
+	}
+
+	ciphertext, err := authenticatedEncrypt(keyEnc, keyMac, iv, plaintext)
+	if err != nil {
+		return "", err
+	}
+
+	encryptedKeys, err := rsa.EncryptOAEP(sha1.New(), random, publicKey, keys, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(encryptedKeys) + base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// authenticatedEncrypt encrypts with AES-256-CBC and authenticates the algorithm identifiers, IV
+// and ciphertext with HMAC-SHA256. The result is algorithms || tag || iv || ciphertext
+func authenticatedEncrypt(keyEnc []byte, keyMac []byte, iv []byte, plaintext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(keyEnc)
+	if err != nil {
+		return nil, err
+	}
+
+	padding := aes.BlockSize - len(plaintext)%aes.BlockSize
+	padded := append(append([]byte{}, plaintext...), bytes.Repeat([]byte{byte(padding)}, padding)...)
+	ciphertext := make([]byte, len(padded))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(ciphertext, padded)
+
+	algorithms := []byte{aes256CbcPkcs7Identifier, hmacSha256Identifier}
+
+	mac := hmac.New(sha256.New, keyMac)
+	mac.Write(algorithms)
+	mac.Write(iv)
+	mac.Write(ciphertext)
+	tag := mac.Sum(nil)
+
+	var result bytes.Buffer
+	result.Write(algorithms)
+	result.Write(tag)
+	result.Write(iv)
+	result.Write(ciphertext)
+	return result.Bytes(), nil
+}
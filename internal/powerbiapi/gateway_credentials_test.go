@@ -0,0 +1,187 @@
+package powerbiapi
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"math/big"
+	"testing"
+)
+
+func sequentialBytes(length int) []byte {
+	b := make([]byte, length)
+	for i := range b {
+		b[i] = byte(i)
+	}
+	return b
+}
+
+func gatewayPublicKeyFromRSA(key *rsa.PublicKey) GatewayPublicKey {
+	return GatewayPublicKey{
+		Modulus:  base64.StdEncoding.EncodeToString(key.N.Bytes()),
+		Exponent: base64.StdEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+// TestBuildCredentials tests the credential JSON sent to the gateway for each credential type
+func TestBuildCredentials(t *testing.T) {
+	basic, _ := BuildBasicCredentials("user", "p\"ss")
+	windows, _ := BuildWindowsCredentials(`domain\user`, "pass")
+	key, _ := BuildKeyCredentials("secret")
+	oauth2, _ := BuildOAuth2Credentials("token")
+
+	tests := []struct {
+		name     string
+		actual   string
+		expected string
+	}{
+		{"Basic", basic, `{"credentialData":[{"name":"username","value":"user"},{"name":"password","value":"p\"ss"}]}`},
+		{"Windows", windows, `{"credentialData":[{"name":"username","value":"domain\\user"},{"name":"password","value":"pass"}]}`},
+		{"Key", key, `{"credentialData":[{"name":"key","value":"secret"}]}`},
+		{"OAuth2", oauth2, `{"credentialData":[{"name":"accessToken","value":"token"}]}`},
+		{"Anonymous", BuildAnonymousCredentials(), `{"credentialData":""}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.actual != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, tt.actual)
+			}
+		})
+	}
+}
+
+// TestAuthenticatedEncrypt tests AES-256-CBC and HMAC-SHA256 output against vectors generated with openssl
+func TestAuthenticatedEncrypt(t *testing.T) {
+	plaintext := `{"credentialData":[{"name":"username","value":"user"},{"name":"password","value":"pass"}]}`
+
+	// openssl enc -aes-256-cbc -K <keyEnc> -iv <iv>
+	expectedCiphertext := "e098e647e778a11af662fbc782c7eb035a519ca12f83bba7766b8b3ef27d13c73edd1b64bf6a8100471fc308ae2b508b90e22e323d6ea0cc6867d379da5b347d4689f35408f3b723e1b366d9fa24c64bbcde5b78f9993995afd0db082267504e"
+	// openssl dgst -sha256 -mac HMAC -macopt hexkey:<keyMac> over 0000 || iv || ciphertext
+	expectedTag := "8c6a32058bcabc9240dc180df30d2871588baecbf9e0d92e49fa2ad55313fde1"
+
+	result, err := authenticatedEncrypt(sequentialBytes(32), sequentialBytes(64), sequentialBytes(16), []byte(plaintext))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "0000" + expectedTag + hex.EncodeToString(sequentialBytes(16)) + expectedCiphertext
+	if actual := hex.EncodeToString(result); actual != expected {
+		t.Errorf("expected %s, got %s", expected, actual)
+	}
+}
+
+// TestEncryptCredentialsHigherKey tests credentials encrypted with a 2048 bit key can be decrypted
+func TestEncryptCredentialsHigherKey(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	credentials, _ := BuildBasicCredentials("user", "pass")
+
+	encrypted, err := EncryptCredentials(credentials, gatewayPublicKeyFromRSA(&privateKey.PublicKey))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the encrypted keys are the first 256 bytes, which is 344 characters of base64
+	encryptedKeys, err := base64.StdEncoding.DecodeString(encrypted[:344])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(encrypted[344:])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	keys, err := rsa.DecryptOAEP(sha1.New(), nil, privateKey, encryptedKeys, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(keys) != 98 || keys[0] != 0 || keys[1] != 1 {
+		t.Fatalf("unexpected key header %x", keys[:2])
+	}
+	keyEnc := keys[2:34]
+	keyMac := keys[34:]
+
+	if ciphertext[0] != 0 || ciphertext[1] != 0 {
+		t.Fatalf("unexpected algorithm header %x", ciphertext[:2])
+	}
+	tag := ciphertext[2:34]
+	iv := ciphertext[34:50]
+	body := ciphertext[50:]
+
+	mac := hmac.New(sha256.New, keyMac)
+	mac.Write(ciphertext[:2])
+	mac.Write(iv)
+	mac.Write(body)
+	if !hmac.Equal(tag, mac.Sum(nil)) {
+		t.Fatalf("tag does not match")
+	}
+
+	block, _ := aes.NewCipher(keyEnc)
+	decrypted := make([]byte, len(body))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(decrypted, body)
+	decrypted = decrypted[:len(decrypted)-int(decrypted[len(decrypted)-1])]
+
+	if string(decrypted) != credentials {
+		t.Errorf("expected %s, got %s", credentials, decrypted)
+	}
+}
+
+// TestEncryptCredentialsLegacyKey tests credentials encrypted with a 1024 bit key are split into segments
+func TestEncryptCredentialsLegacyKey(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	credentials, _ := BuildBasicCredentials("user", string(bytes.Repeat([]byte("p"), 100)))
+
+	encrypted, err := EncryptCredentials(credentials, gatewayPublicKeyFromRSA(&privateKey.PublicKey))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedSegments := (len(credentials) + 84) / 85
+	if len(ciphertext) != expectedSegments*128 {
+		t.Fatalf("expected %d segments, got %d bytes", expectedSegments, len(ciphertext))
+	}
+
+	var decrypted []byte
+	for start := 0; start < len(ciphertext); start += 128 {
+		segment, err := rsa.DecryptOAEP(sha1.New(), nil, privateKey, ciphertext[start:start+128], nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		decrypted = append(decrypted, segment...)
+	}
+
+	if string(decrypted) != credentials {
+		t.Errorf("expected %s, got %s", credentials, decrypted)
+	}
+}
+
+// TestEncryptCredentialsInvalidKey tests a malformed public key is rejected
+func TestEncryptCredentialsInvalidKey(t *testing.T) {
+	_, err := EncryptCredentials("{}", GatewayPublicKey{Modulus: "not base64!", Exponent: "AQAB"})
+	if err == nil {
+		t.Errorf("expected error for invalid modulus")
+	}
+
+	_, err = EncryptCredentials("{}", GatewayPublicKey{})
+	if err == nil {
+		t.Errorf("expected error for empty key")
+	}
+}
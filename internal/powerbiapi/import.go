@@ -75,7 +75,7 @@ type GetImportsInGroupResponseItemReport struct {
 	WebURL     string
 }
 
-// PostImportInGroup creates an import within the the specified group
+// PostImportInGroup creates an import within the the specified group. An empty groupID imports into "My workspace"
 func (client *Client) PostImportInGroup(groupID string, datasetDisplayName string, nameConflict string, skipReport bool, requestData io.Reader) (*PostImportInGroupResponse, error) {
 
 	queryParams := url.Values{}
@@ -90,7 +90,7 @@ func (client *Client) PostImportInGroup(groupID string, datasetDisplayName strin
 	}
 
 	var respObj PostImportInGroupResponse
-	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/groups/%s/imports?%s", url.PathEscape(groupID), queryParams.Encode())
+	url := fmt.Sprintf("%s/imports?%s", groupURL(groupID), queryParams.Encode())
 	err := client.doMultipartJSON("POST", url, requestData, &respObj)
 
 	return &respObj, err
@@ -126,8 +126,8 @@ func (client *Client) GetImportInGroup(groupID string, importID string) (*GetImp
 
 	var respObj GetImportInGroupResponse
 	url := fmt.Sprintf(
-		"https://api.powerbi.com/v1.0/myorg/groups/%s/imports/%s",
-		url.PathEscape(groupID),
+		"%s/imports/%s",
+		groupURL(groupID),
 		url.PathEscape(importID))
 	err := client.doJSON("GET", url, nil, &respObj)
 
@@ -139,8 +139,8 @@ func (client *Client) GetImportsInGroup(groupID string) (*GetImportsInGroupRespo
 
 	var respObj GetImportsInGroupResponse
 	url := fmt.Sprintf(
-		"https://api.powerbi.com/v1.0/myorg/groups/%s/imports",
-		url.PathEscape(groupID))
+		"%s/imports",
+		groupURL(groupID))
 	err := client.doJSON("GET", url, nil, &respObj)
 
 	return &respObj, err
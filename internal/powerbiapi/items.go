@@ -0,0 +1,182 @@
+package powerbiapi
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ItemDefinition represents the definition of a Fabric item, such as the files of a report or semantic model
+type ItemDefinition struct {
+	Format string               `json:"format,omitempty"`
+	Parts  []ItemDefinitionPart `json:"parts"`
+}
+
+// ItemDefinitionPart represents a single file within an item definition
+type ItemDefinitionPart struct {
+	Path        string `json:"path"`
+	Payload     string `json:"payload"`
+	PayloadType string `json:"payloadType"`
+}
+
+// CreateItemRequest represents the request for the CreateItem API
+type CreateItemRequest struct {
+	DisplayName string          `json:"displayName"`
+	Type        string          `json:"type"`
+	Definition  *ItemDefinition `json:"definition,omitempty"`
+}
+
+// UpdateItemRequest represents the request for the UpdateItem API
+type UpdateItemRequest struct {
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+// UpdateItemDefinitionRequest represents the request for the UpdateItemDefinition API
+type UpdateItemDefinitionRequest struct {
+	Definition ItemDefinition `json:"definition"`
+}
+
+// GetItemResponse represents a Fabric item
+type GetItemResponse struct {
+	ID          string
+	DisplayName string
+	Type        string
+	WorkspaceID string
+}
+
+// GetOperationResponse represents the state of a long running Fabric operation
+type GetOperationResponse struct {
+	Status          string
+	PercentComplete int
+	Error           *GetOperationResponseError
+}
+
+// GetOperationResponseError represents the reason a long running Fabric operation failed
+type GetOperationResponseError struct {
+	ErrorCode string
+	Message   string
+}
+
+// CreateItem creates an item within a workspace, waiting for the item to be provisioned.
+func (client *Client) CreateItem(groupID string, request CreateItemRequest, timeout time.Duration) (*GetItemResponse, error) {
+
+	var respObj GetItemResponse
+	url := fmt.Sprintf("%s/workspaces/%s/items", fabricURL, url.PathEscape(groupID))
+	err := client.doLongRunningJSON("POST", url, request, &respObj, timeout)
+
+	return &respObj, err
+}
+
+// GetItem returns an item within a workspace.
+func (client *Client) GetItem(groupID string, itemID string) (*GetItemResponse, error) {
+
+	var respObj GetItemResponse
+	url := fmt.Sprintf("%s/workspaces/%s/items/%s", fabricURL, url.PathEscape(groupID), url.PathEscape(itemID))
+	err := client.doJSON("GET", url, nil, &respObj)
+
+	return &respObj, err
+}
+
+// UpdateItem updates the properties of an item within a workspace.
+func (client *Client) UpdateItem(groupID string, itemID string, request UpdateItemRequest) error {
+
+	url := fmt.Sprintf("%s/workspaces/%s/items/%s", fabricURL, url.PathEscape(groupID), url.PathEscape(itemID))
+	err := client.doJSON("PATCH", url, request, nil)
+
+	return err
+}
+
+// UpdateItemDefinition replaces the definition of an item within a workspace, waiting for the update to complete.
+func (client *Client) UpdateItemDefinition(groupID string, itemID string, request UpdateItemDefinitionRequest, timeout time.Duration) error {
+
+	url := fmt.Sprintf("%s/workspaces/%s/items/%s/updateDefinition", fabricURL, url.PathEscape(groupID), url.PathEscape(itemID))
+	err := client.doLongRunningJSON("POST", url, request, nil, timeout)
+
+	return err
+}
+
+// DeleteItem deletes an item within a workspace.
+func (client *Client) DeleteItem(groupID string, itemID string) error {
+
+	url := fmt.Sprintf("%s/workspaces/%s/items/%s", fabricURL, url.PathEscape(groupID), url.PathEscape(itemID))
+	err := client.doJSON("DELETE", url, nil, nil)
+
+	return err
+}
+
+// GetOperation returns the state of a long running operation.
+func (client *Client) GetOperation(operationID string) (*GetOperationResponse, error) {
+
+	var respObj GetOperationResponse
+	url := fmt.Sprintf("%s/operations/%s", fabricURL, url.PathEscape(operationID))
+	err := client.doJSON("GET", url, nil, &respObj)
+
+	return &respObj, err
+}
+
+// doLongRunningJSON calls a Fabric API that may complete asynchronously. Accepted requests are
+// polled until the operation completes, after which the result of the operation is returned
+func (client *Client) doLongRunningJSON(method string, url string, body interface{}, response interface{}, timeout time.Duration) error {
+
+	httpRequest, err := newJSONRequest(method, url, body)
+	if err != nil {
+		return err
+	}
+
+	httpResponse, err := client.Do(httpRequest)
+	if err != nil {
+		return err
+	}
+	defer httpResponse.Body.Close()
+
+	if httpResponse.StatusCode != 202 {
+		return newJSONResponse(httpResponse, response)
+	}
+
+	operationID := httpResponse.Header.Get("x-ms-operation-id")
+	if operationID == "" {
+		return fmt.Errorf("accepted response from %s did not include an operation ID", url)
+	}
+
+	pollInterval := time.Second
+	if retryAfter, err := strconv.Atoi(httpResponse.Header.Get("Retry-After")); err == nil && retryAfter > 0 {
+		pollInterval = time.Duration(retryAfter) * time.Second
+	}
+
+	err = client.waitForOperationToSucceed(operationID, pollInterval, timeout)
+	if err != nil || response == nil {
+		return err
+	}
+
+	resultURL := fmt.Sprintf("%s/operations/%s/result", fabricURL, operationID)
+	return client.doJSON("GET", resultURL, nil, response)
+}
+
+func (client *Client) waitForOperationToSucceed(operationID string, pollInterval time.Duration, timeout time.Duration) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	started := time.Now()
+	for {
+		now := <-ticker.C
+
+		operation, err := client.GetOperation(operationID)
+		if err != nil {
+			return err
+		}
+
+		if operation.Status == "Succeeded" {
+			return nil
+		} else if operation.Status != "NotStarted" && operation.Status != "Running" {
+			if operation.Error != nil {
+				return fmt.Errorf("Operation completed with invalid state '%s': %s %s", operation.Status, operation.Error.ErrorCode, operation.Error.Message)
+			}
+			return fmt.Errorf("Operation completed with invalid state '%s'", operation.Status)
+		}
+
+		if now.Sub(started) > timeout {
+			return fmt.Errorf("Timed out waiting for operation to complete. Operation taking longer than %v seconds", timeout.Seconds())
+		}
+	}
+}
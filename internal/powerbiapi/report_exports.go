@@ -0,0 +1,143 @@
+package powerbiapi
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ExportReportInGroupRequest represents the request for the ExportReportInGroup API
+type ExportReportInGroupRequest struct {
+	Format                     string                            `json:"format"`
+	PowerBIReportConfiguration *PowerBIReportExportConfiguration `json:"powerBIReportConfiguration,omitempty"`
+}
+
+// PowerBIReportExportConfiguration represents the configuration used to export a Power BI report
+type PowerBIReportExportConfiguration struct {
+	Pages              []ExportReportPage    `json:"pages,omitempty"`
+	DefaultBookmark    *PageBookmark         `json:"defaultBookmark,omitempty"`
+	ReportLevelFilters []ExportFilter        `json:"reportLevelFilters,omitempty"`
+	Identities         []EffectiveIdentity   `json:"identities,omitempty"`
+	Settings           *ExportReportSettings `json:"settings,omitempty"`
+}
+
+// ExportReportPage represents a single page to export
+type ExportReportPage struct {
+	PageName string        `json:"pageName"`
+	Bookmark *PageBookmark `json:"bookmark,omitempty"`
+}
+
+// PageBookmark represents a bookmark to apply when exporting
+type PageBookmark struct {
+	Name  string `json:"name,omitempty"`
+	State string `json:"state,omitempty"`
+}
+
+// ExportFilter represents a report level filter in OData filter syntax
+type ExportFilter struct {
+	Filter string `json:"filter"`
+}
+
+// EffectiveIdentity represents the identity used to apply row level security
+type EffectiveIdentity struct {
+	Username string   `json:"username"`
+	Roles    []string `json:"roles,omitempty"`
+	Datasets []string `json:"datasets,omitempty"`
+}
+
+// ExportReportSettings represents the settings used when exporting a report
+type ExportReportSettings struct {
+	Locale             string `json:"locale,omitempty"`
+	IncludeHiddenPages bool   `json:"includeHiddenPages,omitempty"`
+}
+
+// ExportReportInGroupResponse represents the status of an export job
+type ExportReportInGroupResponse struct {
+	ID                    string
+	CreatedDateTime       string
+	LastActionDateTime    string
+	ReportID              string
+	ReportName            string
+	Status                string
+	PercentComplete       int
+	ResourceLocation      string
+	ResourceFileExtension string
+	ExpirationTime        string
+}
+
+// ExportReportInGroup starts an asynchronous job exporting the specified report to a file.
+func (client *Client) ExportReportInGroup(groupID string, reportID string, request ExportReportInGroupRequest) (*ExportReportInGroupResponse, error) {
+
+	var respObj ExportReportInGroupResponse
+	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/groups/%s/reports/%s/ExportTo", url.PathEscape(groupID), url.PathEscape(reportID))
+	err := client.doJSON("POST", url, request, &respObj)
+
+	return &respObj, err
+}
+
+// GetExportReportInGroup returns the status of an export job.
+func (client *Client) GetExportReportInGroup(groupID string, reportID string, exportID string) (*ExportReportInGroupResponse, error) {
+
+	var respObj ExportReportInGroupResponse
+	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/groups/%s/reports/%s/exports/%s", url.PathEscape(groupID), url.PathEscape(reportID), url.PathEscape(exportID))
+	err := client.doJSON("GET", url, nil, &respObj)
+
+	return &respObj, err
+}
+
+// WaitForExportReportInGroupToSucceed waits until the specified export job succeeds
+func (client *Client) WaitForExportReportInGroupToSucceed(groupID string, reportID string, exportID string, timeout time.Duration) (*ExportReportInGroupResponse, error) {
+	// exports take at least several seconds so there is no need to poll as often as imports
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+
+	started := time.Now()
+	for {
+		export, err := client.GetExportReportInGroup(groupID, reportID, exportID)
+		if err != nil {
+			return nil, err
+		}
+
+		if export.Status == "Succeeded" {
+			return export, nil
+		} else if export.Status != "NotStarted" && export.Status != "Running" {
+			return export, fmt.Errorf("Export completed with invalid state '%s'", export.Status)
+		}
+
+		now := <-ticker.C
+		if now.Sub(started) > timeout {
+			return nil, fmt.Errorf("Timed out waiting for export to complete. Export taking longer than %v seconds", timeout.Seconds())
+		}
+	}
+}
+
+// GetExportReportFileInGroup returns the file produced by a successful export job. The caller must close the returned reader.
+func (client *Client) GetExportReportFileInGroup(groupID string, reportID string, exportID string) (io.ReadCloser, error) {
+
+	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/groups/%s/reports/%s/exports/%s/file", url.PathEscape(groupID), url.PathEscape(reportID), url.PathEscape(exportID))
+	return client.doDownload(url)
+}
+
+// ExportReportPBIXInGroup returns the PBIX file of the specified report. The caller must close the returned reader.
+func (client *Client) ExportReportPBIXInGroup(groupID string, reportID string) (io.ReadCloser, error) {
+
+	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/groups/%s/reports/%s/Export", url.PathEscape(groupID), url.PathEscape(reportID))
+	return client.doDownload(url)
+}
+
+func (client *Client) doDownload(url string) (io.ReadCloser, error) {
+
+	httpRequest, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	httpResponse, err := client.Do(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return httpResponse.Body, nil
+}
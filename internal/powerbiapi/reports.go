@@ -10,6 +10,47 @@ type RebindReportInGroupRequest struct {
 	DatasetID string `json:"datasetId"`
 }
 
+// CloneReportInGroupRequest represents the request for the CloneReportInGroup API
+type CloneReportInGroupRequest struct {
+	Name              string `json:"name"`
+	TargetWorkspaceID string `json:"targetWorkspaceId,omitempty"`
+	TargetModelID     string `json:"targetModelId,omitempty"`
+}
+
+// UpdateReportContentInGroupRequest represents the request for the UpdateReportContentInGroup API
+type UpdateReportContentInGroupRequest struct {
+	SourceReport UpdateReportContentInGroupRequestSourceReport `json:"sourceReport"`
+	SourceType   string                                        `json:"sourceType"`
+}
+
+// UpdateReportContentInGroupRequestSourceReport represents the report the content is copied from
+type UpdateReportContentInGroupRequestSourceReport struct {
+	SourceReportID    string `json:"sourceReportId"`
+	SourceWorkspaceID string `json:"sourceWorkspaceId"`
+}
+
+// UpdateReportInGroupRequest represents the request for the UpdateReportInGroup API
+type UpdateReportInGroupRequest struct {
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+// UpdateRDLDatasourcesInGroupRequest represents the request to update the datasources of a paginated report
+type UpdateRDLDatasourcesInGroupRequest struct {
+	UpdateDetails []UpdateRDLDatasourcesInGroupRequestItem `json:"updateDetails"`
+}
+
+// UpdateRDLDatasourcesInGroupRequestItem represents a single paginated report datasource update
+type UpdateRDLDatasourcesInGroupRequestItem struct {
+	DatasourceName    string                                                  `json:"datasourceName"`
+	ConnectionDetails UpdateRDLDatasourcesInGroupRequestItemConnectionDetails `json:"connectionDetails"`
+}
+
+// UpdateRDLDatasourcesInGroupRequestItemConnectionDetails represents connection details for a single paginated report datasource
+type UpdateRDLDatasourcesInGroupRequestItemConnectionDetails struct {
+	Server   string `json:"server"`
+	Database string `json:"database,omitempty"`
+}
+
 // GetReportsInGroupResponse represents the details when getting a report in a group.
 type GetReportsInGroupResponse struct {
 	Value []GetReportsInGroupResponseItem
@@ -17,27 +58,42 @@ type GetReportsInGroupResponse struct {
 
 // GetReportsInGroupResponseItem represents a single dataset
 type GetReportsInGroupResponseItem struct {
-	ID        string
-	Name      string
-	DatasetID string
-	WebURL    string
-	EmbedURL  string
+	ID         string
+	Name       string
+	DatasetID  string
+	WebURL     string
+	EmbedURL   string
+	ReportType string
+}
+
+// GetPagesInGroupResponse represents the pages of a report
+type GetPagesInGroupResponse struct {
+	Value []GetPagesInGroupResponseItem
+}
+
+// GetPagesInGroupResponseItem represents a single page of a report
+type GetPagesInGroupResponseItem struct {
+	Name        string
+	DisplayName string
+	Order       int
 }
 
 // GetReportsInGroupResponse represents the details when getting a report in a group.
 type GetReportInGroupResponse struct {
-	ID        string
-	Name      string
-	DatasetID string
-	WebURL    string
-	EmbedURL  string
+	ID          string
+	Name        string
+	DatasetID   string
+	WebURL      string
+	EmbedURL    string
+	ReportType  string
+	IsOwnedByMe bool
 }
 
 // GetReportsInGroup returns a list of reports within the specified group.
 func (client *Client) GetReportsInGroup(groupID string) (*GetReportsInGroupResponse, error) {
 
 	var respObj GetReportsInGroupResponse
-	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/groups/%s/reports", url.PathEscape(groupID))
+	url := fmt.Sprintf("%s/reports", groupURL(groupID))
 	err := client.doJSON("GET", url, nil, &respObj)
 
 	return &respObj, err
@@ -47,7 +103,17 @@ func (client *Client) GetReportsInGroup(groupID string) (*GetReportsInGroupRespo
 func (client *Client) GetReportInGroup(groupID string, reportID string) (*GetReportInGroupResponse, error) {
 
 	var respObj GetReportInGroupResponse
-	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/groups/%s/reports/%s", url.PathEscape(groupID), url.PathEscape(reportID))
+	url := fmt.Sprintf("%s/reports/%s", groupURL(groupID), url.PathEscape(reportID))
+	err := client.doJSON("GET", url, nil, &respObj)
+
+	return &respObj, err
+}
+
+// GetPagesInGroup returns the pages of a report that exists within a group
+func (client *Client) GetPagesInGroup(groupID string, reportID string) (*GetPagesInGroupResponse, error) {
+
+	var respObj GetPagesInGroupResponse
+	url := fmt.Sprintf("%s/reports/%s/pages", groupURL(groupID), url.PathEscape(reportID))
 	err := client.doJSON("GET", url, nil, &respObj)
 
 	return &respObj, err
@@ -56,7 +122,7 @@ func (client *Client) GetReportInGroup(groupID string, reportID string) (*GetRep
 // DeleteReportInGroup deletes a report that exists within a group.
 func (client *Client) DeleteReportInGroup(groupID string, reportID string) error {
 
-	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/groups/%s/reports/%s", url.PathEscape(groupID), url.PathEscape(reportID))
+	url := fmt.Sprintf("%s/reports/%s", groupURL(groupID), url.PathEscape(reportID))
 	err := client.doJSON("DELETE", url, nil, nil)
 
 	return err
@@ -65,7 +131,65 @@ func (client *Client) DeleteReportInGroup(groupID string, reportID string) error
 // RebindReportInGroup rebinds the specified report from the specified group to the requested dataset.
 func (client *Client) RebindReportInGroup(groupID string, reportID string, request RebindReportInGroupRequest) error {
 
-	url := fmt.Sprintf("https://api.powerbi.com/v1.0/myorg/groups/%s/reports/%s/Rebind", url.PathEscape(groupID), url.PathEscape(reportID))
+	url := fmt.Sprintf("%s/reports/%s/Rebind", groupURL(groupID), url.PathEscape(reportID))
+	err := client.doJSON("POST", url, request, nil)
+
+	return err
+}
+
+// TakeOverReportInGroup transfers ownership of a paginated report within a group to the current authorized user.
+func (client *Client) TakeOverReportInGroup(groupID string, reportID string) error {
+
+	url := fmt.Sprintf("%s/reports/%s/Default.TakeOver", groupURL(groupID), url.PathEscape(reportID))
+	err := client.doJSON("POST", url, nil, nil)
+
+	return err
+}
+
+// CloneReportInGroup clones the specified report from the specified group, optionally into another workspace and bound to another dataset.
+func (client *Client) CloneReportInGroup(groupID string, reportID string, request CloneReportInGroupRequest) (*GetReportInGroupResponse, error) {
+
+	var respObj GetReportInGroupResponse
+	url := fmt.Sprintf("%s/reports/%s/Clone", groupURL(groupID), url.PathEscape(reportID))
+	err := client.doJSON("POST", url, request, &respObj)
+
+	return &respObj, err
+}
+
+// UpdateReportContentInGroup replaces the content of the specified report with the content of the source report.
+func (client *Client) UpdateReportContentInGroup(groupID string, reportID string, request UpdateReportContentInGroupRequest) (*GetReportInGroupResponse, error) {
+
+	var respObj GetReportInGroupResponse
+	url := fmt.Sprintf("%s/reports/%s/UpdateReportContent", groupURL(groupID), url.PathEscape(reportID))
+	err := client.doJSON("POST", url, request, &respObj)
+
+	return &respObj, err
+}
+
+// UpdateReportInGroup updates the properties of a report. The Power BI API has no way to rename
+// a report so this uses the Fabric items API, which accepts the same access token.
+func (client *Client) UpdateReportInGroup(groupID string, reportID string, request UpdateReportInGroupRequest) error {
+
+	url := fmt.Sprintf("%s/workspaces/%s/reports/%s", fabricURL, url.PathEscape(groupID), url.PathEscape(reportID))
+	err := client.doJSON("PATCH", url, request, nil)
+
+	return err
+}
+
+// GetDatasourcesOfReportInGroup returns the datasources of a paginated report that exists within a group.
+func (client *Client) GetDatasourcesOfReportInGroup(groupID string, reportID string) (*GetDatasourcesInGroupResponse, error) {
+
+	var respObj GetDatasourcesInGroupResponse
+	url := fmt.Sprintf("%s/reports/%s/datasources", groupURL(groupID), url.PathEscape(reportID))
+	err := client.doJSON("GET", url, nil, &respObj)
+
+	return &respObj, err
+}
+
+// UpdateDatasourcesOfReportInGroup updates the datasources of a paginated report that exists within a group.
+func (client *Client) UpdateDatasourcesOfReportInGroup(groupID string, reportID string, request UpdateRDLDatasourcesInGroupRequest) error {
+
+	url := fmt.Sprintf("%s/reports/%s/Default.UpdateDatasources", groupURL(groupID), url.PathEscape(reportID))
 	err := client.doJSON("POST", url, request, nil)
 
 	return err
@@ -0,0 +1,245 @@
+package workspaceexport
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// hclBlock represents a resource or import block, attributes are written in order
+type hclBlock struct {
+	Type       string
+	Labels     []string
+	Attributes []hclAttribute
+}
+
+// hclAttribute represents an attribute with a value that is already an HCL expression
+type hclAttribute struct {
+	Name  string
+	Value string
+}
+
+// WriteHCL writes resources and import blocks for workspaces. Running terraform plan against the
+// output imports the existing objects without changing them
+func WriteHCL(w io.Writer, workspaces []*Workspace) error {
+	names := resourceNames{}
+	for i, workspace := range workspaces {
+		if i > 0 {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+		if err := writeWorkspaceHCL(w, workspace, names); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeWorkspaceHCL(w io.Writer, workspace *Workspace, names resourceNames) error {
+	group := workspace.Group
+	workspaceName := names.New("powerbi_workspace", group.Name)
+	workspaceRef := "powerbi_workspace." + workspaceName + ".id"
+
+	fmt.Fprintf(w, "# Workspace %s\n\n", group.Name)
+
+	blocks := []hclBlock{}
+	addResource := func(resourceType string, name string, importID string, attributes ...hclAttribute) {
+		blocks = append(blocks,
+			hclBlock{Type: "resource", Labels: []string{resourceType, name}, Attributes: attributes},
+			hclBlock{Type: "import", Attributes: []hclAttribute{
+				{"to", resourceType + "." + name},
+				{"id", hclString(importID)},
+			}},
+		)
+	}
+
+	workspaceAttributes := []hclAttribute{{"name", hclString(group.Name)}}
+	if group.CapacityID != "" {
+		workspaceAttributes = append(workspaceAttributes, hclAttribute{"capacity_id", hclString(group.CapacityID)})
+	}
+	addResource("powerbi_workspace", workspaceName, group.ID, workspaceAttributes...)
+
+	for _, user := range workspace.Users {
+		userName := user.EmailAddress
+		if userName == "" {
+			userName = firstNonEmpty(user.DisplayName, user.Identifier)
+		}
+		attributes := []hclAttribute{
+			{"workspace_id", workspaceRef},
+			{"principal_type", hclString(user.PrincipalType)},
+			{"identifier", hclString(user.Identifier)},
+		}
+		if user.EmailAddress != "" {
+			attributes = append(attributes, hclAttribute{"email_address", hclString(user.EmailAddress)})
+		}
+		attributes = append(attributes, hclAttribute{"group_user_access_right", hclString(user.GroupUserAccessRight)})
+		addResource("powerbi_workspace_access", names.New("powerbi_workspace_access", workspaceName+"_"+userName), group.ID+"/"+user.Identifier, attributes...)
+	}
+
+	for _, dataset := range workspace.Datasets {
+		schedule := dataset.RefreshSchedule
+		if schedule == nil {
+			continue
+		}
+		addResource("powerbi_refresh_schedule", names.New("powerbi_refresh_schedule", workspaceName+"_"+dataset.Dataset.Name), group.ID+"/"+dataset.Dataset.ID,
+			hclAttribute{"workspace_id", workspaceRef},
+			hclAttribute{"dataset_id", hclString(dataset.Dataset.ID)},
+			hclAttribute{"days", hclStringList(schedule.Days)},
+			hclAttribute{"times", hclStringList(schedule.Times)},
+			hclAttribute{"enabled", strconv.FormatBool(schedule.Enabled)},
+			hclAttribute{"local_time_zone_id", hclString(firstNonEmpty(schedule.LocalTimeZoneID, "UTC"))},
+			hclAttribute{"notify_option", hclString(firstNonEmpty(schedule.NotifyOption, "NoNotification"))},
+		)
+	}
+
+	for _, dashboard := range workspace.Dashboards {
+		addResource("powerbi_dashboard", names.New("powerbi_dashboard", workspaceName+"_"+dashboard.DisplayName), group.ID+"/"+dashboard.ID,
+			hclAttribute{"workspace_id", workspaceRef},
+			hclAttribute{"name", hclString(dashboard.DisplayName)},
+		)
+	}
+
+	for _, dataflow := range workspace.Dataflows {
+		dataflowName := names.New("powerbi_dataflow", workspaceName+"_"+dataflow.Dataflow.Name)
+		attributes := []hclAttribute{
+			{"workspace_id", workspaceRef},
+			{"name", hclString(dataflow.Dataflow.Name)},
+		}
+		if dataflow.Dataflow.Description != "" {
+			attributes = append(attributes, hclAttribute{"description", hclString(dataflow.Dataflow.Description)})
+		}
+		addResource("powerbi_dataflow", dataflowName, group.ID+"/"+dataflow.Dataflow.ObjectID, attributes...)
+
+		schedule := dataflow.RefreshSchedule
+		if schedule == nil {
+			continue
+		}
+		attributes = []hclAttribute{
+			{"workspace_id", workspaceRef},
+			{"dataflow_id", "powerbi_dataflow." + dataflowName + ".id"},
+			{"enabled", strconv.FormatBool(schedule.Enabled)},
+		}
+		if len(schedule.Days) > 0 {
+			attributes = append(attributes, hclAttribute{"days", hclStringList(schedule.Days)})
+		}
+		if len(schedule.Times) > 0 {
+			attributes = append(attributes, hclAttribute{"times", hclStringList(schedule.Times)})
+		}
+		if schedule.LocalTimeZoneID != "" {
+			attributes = append(attributes, hclAttribute{"local_time_zone_id", hclString(schedule.LocalTimeZoneID)})
+		}
+		attributes = append(attributes, hclAttribute{"notify_option", hclString(firstNonEmpty(schedule.NotifyOption, "NoNotification"))})
+		addResource("powerbi_dataflow_refresh_schedule", names.New("powerbi_dataflow_refresh_schedule", dataflowName), group.ID+"/"+dataflow.Dataflow.ObjectID, attributes...)
+	}
+
+	for _, block := range blocks {
+		if _, err := io.WriteString(w, block.String()+"\n"); err != nil {
+			return err
+		}
+	}
+
+	// datasets and reports are deployed from files that cannot be downloaded in a form the provider can deploy
+	if len(workspace.Datasets) > 0 || len(workspace.Reports) > 0 {
+		lines := []string{"# Datasets and reports are not exported, deploy them with powerbi_pbix or powerbi_pbip:"}
+		for _, dataset := range workspace.Datasets {
+			lines = append(lines, fmt.Sprintf("#   dataset %q (%s)", dataset.Dataset.Name, dataset.Dataset.ID))
+		}
+		for _, report := range workspace.Reports {
+			lines = append(lines, fmt.Sprintf("#   report %q (%s) using dataset %s", report.Name, report.ID, report.DatasetID))
+		}
+		if _, err := io.WriteString(w, strings.Join(lines, "\n")+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// String formats the block as terraform fmt would, aligning the equals signs of attributes
+func (block hclBlock) String() string {
+	var builder strings.Builder
+	builder.WriteString(block.Type)
+	for _, label := range block.Labels {
+		builder.WriteString(" " + hclString(label))
+	}
+	builder.WriteString(" {\n")
+
+	width := 0
+	for _, attribute := range block.Attributes {
+		if len(attribute.Name) > width {
+			width = len(attribute.Name)
+		}
+	}
+	for _, attribute := range block.Attributes {
+		fmt.Fprintf(&builder, "  %-*s = %s\n", width, attribute.Name, attribute.Value)
+	}
+	builder.WriteString("}\n")
+	return builder.String()
+}
+
+// hclString quotes a value as an HCL string, escaping template sequences
+func hclString(value string) string {
+	var builder strings.Builder
+	builder.WriteString(`"`)
+	for i, r := range value {
+		switch {
+		case r == '"' || r == '\\':
+			builder.WriteString(`\` + string(r))
+		case r == '\n':
+			builder.WriteString(`\n`)
+		case r == '\r':
+			builder.WriteString(`\r`)
+		case r == '\t':
+			builder.WriteString(`\t`)
+		case r < ' ':
+			fmt.Fprintf(&builder, `\u%04x`, r)
+		case (r == '$' || r == '%') && strings.HasPrefix(value[i+1:], "{"):
+			builder.WriteString(string(r) + string(r))
+		default:
+			builder.WriteRune(r)
+		}
+	}
+	builder.WriteString(`"`)
+	return builder.String()
+}
+
+func hclStringList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = hclString(value)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+var invalidNameCharacters = regexp.MustCompile(`[^a-z0-9]+`)
+
+// resourceNames allocates Terraform resource names that are unique per resource type
+type resourceNames map[string]bool
+
+// New returns a valid resource name based on a display name, numbering names that are already used
+func (names resourceNames) New(resourceType string, displayName string) string {
+	name := strings.Trim(invalidNameCharacters.ReplaceAllString(strings.ToLower(displayName), "_"), "_")
+	if name == "" {
+		name = strings.TrimPrefix(resourceType, "powerbi_")
+	} else if name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+
+	uniqueName := name
+	for i := 2; names[resourceType+"."+uniqueName]; i++ {
+		uniqueName = fmt.Sprintf("%s_%d", name, i)
+	}
+	names[resourceType+"."+uniqueName] = true
+	return uniqueName
+}
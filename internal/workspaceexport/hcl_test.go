@@ -0,0 +1,175 @@
+package workspaceexport
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/codecutout/terraform-provider-powerbi/internal/powerbiapi"
+)
+
+func TestWriteHCL(t *testing.T) {
+	workspace := &Workspace{
+		Group: powerbiapi.GetGroupResponse{ID: "ws-1", Name: "Sales & Marketing", CapacityID: "cap-1"},
+		Users: []powerbiapi.GetGroupUsersResponseItem{
+			{Identifier: "jane@example.com", EmailAddress: "jane@example.com", GroupUserAccessRight: "Admin", PrincipalType: "User"},
+			{Identifier: "app-1", DisplayName: "Deploy App", GroupUserAccessRight: "Member", PrincipalType: "App"},
+		},
+		Datasets: []Dataset{
+			{
+				Dataset: powerbiapi.GetDatasetsInGroupResponseItem{ID: "ds-1", Name: "Sales"},
+				RefreshSchedule: &powerbiapi.GetRefreshScheduleInGroupResponse{
+					Enabled: true, Days: []string{"Monday", "Friday"}, Times: []string{"07:00"}, LocalTimeZoneID: "UTC", NotifyOption: "MailOnFailure",
+				},
+			},
+			{Dataset: powerbiapi.GetDatasetsInGroupResponseItem{ID: "ds-2", Name: "Sales"}},
+		},
+		Reports: []powerbiapi.GetReportsInGroupResponseItem{
+			{ID: "rp-1", Name: "Sales", DatasetID: "ds-1"},
+		},
+		Dashboards: []powerbiapi.Dashboard{
+			{ID: "db-1", DisplayName: "2024 ${Overview}"},
+		},
+		Dataflows: []Dataflow{
+			{
+				Dataflow:        powerbiapi.Dataflow{ObjectID: "df-1", Name: "Staging", Description: "Loads \"raw\" data"},
+				RefreshSchedule: &powerbiapi.DataflowRefreshSchedule{Enabled: false},
+			},
+		},
+	}
+
+	var builder strings.Builder
+	if err := WriteHCL(&builder, []*Workspace{workspace}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `# Workspace Sales & Marketing
+
+resource "powerbi_workspace" "sales_marketing" {
+  name        = "Sales & Marketing"
+  capacity_id = "cap-1"
+}
+
+import {
+  to = powerbi_workspace.sales_marketing
+  id = "ws-1"
+}
+
+resource "powerbi_workspace_access" "sales_marketing_jane_example_com" {
+  workspace_id            = powerbi_workspace.sales_marketing.id
+  principal_type          = "User"
+  identifier              = "jane@example.com"
+  email_address           = "jane@example.com"
+  group_user_access_right = "Admin"
+}
+
+import {
+  to = powerbi_workspace_access.sales_marketing_jane_example_com
+  id = "ws-1/jane@example.com"
+}
+
+resource "powerbi_workspace_access" "sales_marketing_deploy_app" {
+  workspace_id            = powerbi_workspace.sales_marketing.id
+  principal_type          = "App"
+  identifier              = "app-1"
+  group_user_access_right = "Member"
+}
+
+import {
+  to = powerbi_workspace_access.sales_marketing_deploy_app
+  id = "ws-1/app-1"
+}
+
+resource "powerbi_refresh_schedule" "sales_marketing_sales" {
+  workspace_id       = powerbi_workspace.sales_marketing.id
+  dataset_id         = "ds-1"
+  days               = ["Monday", "Friday"]
+  times              = ["07:00"]
+  enabled            = true
+  local_time_zone_id = "UTC"
+  notify_option      = "MailOnFailure"
+}
+
+import {
+  to = powerbi_refresh_schedule.sales_marketing_sales
+  id = "ws-1/ds-1"
+}
+
+resource "powerbi_dashboard" "sales_marketing_2024_overview" {
+  workspace_id = powerbi_workspace.sales_marketing.id
+  name         = "2024 $${Overview}"
+}
+
+import {
+  to = powerbi_dashboard.sales_marketing_2024_overview
+  id = "ws-1/db-1"
+}
+
+resource "powerbi_dataflow" "sales_marketing_staging" {
+  workspace_id = powerbi_workspace.sales_marketing.id
+  name         = "Staging"
+  description  = "Loads \"raw\" data"
+}
+
+import {
+  to = powerbi_dataflow.sales_marketing_staging
+  id = "ws-1/df-1"
+}
+
+resource "powerbi_dataflow_refresh_schedule" "sales_marketing_staging" {
+  workspace_id  = powerbi_workspace.sales_marketing.id
+  dataflow_id   = powerbi_dataflow.sales_marketing_staging.id
+  enabled       = false
+  notify_option = "NoNotification"
+}
+
+import {
+  to = powerbi_dataflow_refresh_schedule.sales_marketing_staging
+  id = "ws-1/df-1"
+}
+
+# Datasets and reports are not exported, deploy them with powerbi_pbix or powerbi_pbip:
+#   dataset "Sales" (ds-1)
+#   dataset "Sales" (ds-2)
+#   report "Sales" (rp-1) using dataset ds-1
+`
+	if builder.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, builder.String())
+	}
+}
+
+func TestResourceNames(t *testing.T) {
+	names := resourceNames{}
+	tests := []struct {
+		resourceType string
+		displayName  string
+		expected     string
+	}{
+		{"powerbi_dashboard", "Sales Overview", "sales_overview"},
+		{"powerbi_dashboard", "sales-overview", "sales_overview_2"},
+		{"powerbi_dataflow", "Sales Overview", "sales_overview"},
+		{"powerbi_dashboard", "2024", "_2024"},
+		{"powerbi_dashboard", "日本", "dashboard"},
+	}
+
+	for _, test := range tests {
+		if name := names.New(test.resourceType, test.displayName); name != test.expected {
+			t.Errorf("expected name %s for %s %q, got %s", test.expected, test.resourceType, test.displayName, name)
+		}
+	}
+}
+
+func TestHCLString(t *testing.T) {
+	tests := map[string]string{
+		`plain`:           `"plain"`,
+		"line\nbreak":     `"line\nbreak"`,
+		`quote " slash \`: `"quote \" slash \\"`,
+		`${var} %{if}`:    `"$${var} %%{if}"`,
+		`$ and % alone`:   `"$ and % alone"`,
+	}
+
+	for value, expected := range tests {
+		if actual := hclString(value); actual != expected {
+			t.Errorf("expected %s, got %s", expected, actual)
+		}
+	}
+}
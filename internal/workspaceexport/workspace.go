@@ -0,0 +1,95 @@
+package workspaceexport
+
+import (
+	"fmt"
+
+	"github.com/codecutout/terraform-provider-powerbi/internal/powerbiapi"
+)
+
+// Workspace represents the contents of a workspace that can be written as Terraform configuration
+type Workspace struct {
+	Group      powerbiapi.GetGroupResponse
+	Users      []powerbiapi.GetGroupUsersResponseItem
+	Datasets   []Dataset
+	Reports    []powerbiapi.GetReportsInGroupResponseItem
+	Dashboards []powerbiapi.Dashboard
+	Dataflows  []Dataflow
+}
+
+// Dataset represents a dataset within a workspace and its refresh schedule, if it has one
+type Dataset struct {
+	Dataset         powerbiapi.GetDatasetsInGroupResponseItem
+	RefreshSchedule *powerbiapi.GetRefreshScheduleInGroupResponse
+}
+
+// Dataflow represents a dataflow within a workspace and its refresh schedule, if it has one
+type Dataflow struct {
+	Dataflow        powerbiapi.Dataflow
+	RefreshSchedule *powerbiapi.DataflowRefreshSchedule
+}
+
+// ReadWorkspace reads the users, datasets, reports, dashboards, dataflows and refresh schedules of a workspace
+func ReadWorkspace(client *powerbiapi.Client, groupID string) (*Workspace, error) {
+	group, err := client.GetGroup(groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workspace %s: %w", groupID, err)
+	}
+	if group == nil {
+		return nil, fmt.Errorf("workspace %s not found", groupID)
+	}
+	workspace := Workspace{Group: *group}
+
+	users, err := client.GetGroupUsers(groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read users of workspace %s: %w", groupID, err)
+	}
+	workspace.Users = users.Value
+
+	datasets, err := client.GetDatasetsInGroup(groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read datasets of workspace %s: %w", groupID, err)
+	}
+	for _, dataset := range datasets.Value {
+		exportDataset := Dataset{Dataset: dataset}
+		if dataset.IsRefreshable {
+			refreshSchedule, err := client.GetRefreshScheduleInGroup(groupID, dataset.ID)
+			if err != nil && !powerbiapi.IsHTTP404Error(err) {
+				return nil, fmt.Errorf("failed to read refresh schedule of dataset %s: %w", dataset.Name, err)
+			}
+			if err == nil && len(refreshSchedule.Days) > 0 && len(refreshSchedule.Times) > 0 {
+				exportDataset.RefreshSchedule = refreshSchedule
+			}
+		}
+		workspace.Datasets = append(workspace.Datasets, exportDataset)
+	}
+
+	reports, err := client.GetReportsInGroup(groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reports of workspace %s: %w", groupID, err)
+	}
+	workspace.Reports = reports.Value
+
+	dashboards, err := client.GetDashboards(groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dashboards of workspace %s: %w", groupID, err)
+	}
+	workspace.Dashboards = dashboards.Value
+
+	dataflows, err := client.GetDataflows(groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dataflows of workspace %s: %w", groupID, err)
+	}
+	for _, dataflow := range dataflows.Value {
+		exportDataflow := Dataflow{Dataflow: dataflow}
+		refreshSchedule, err := client.GetDataflowRefreshSchedule(groupID, dataflow.ObjectID)
+		if err != nil && !powerbiapi.IsHTTP404Error(err) {
+			return nil, fmt.Errorf("failed to read refresh schedule of dataflow %s: %w", dataflow.Name, err)
+		}
+		if err == nil && (refreshSchedule.Enabled || len(refreshSchedule.Days) > 0) {
+			exportDataflow.RefreshSchedule = refreshSchedule
+		}
+		workspace.Dataflows = append(workspace.Dataflows, exportDataflow)
+	}
+
+	return &workspace, nil
+}